@@ -0,0 +1,28 @@
+package bus
+
+import (
+	"sync"
+
+	"github.com/wagoodman/go-partybus"
+)
+
+var (
+	lock      sync.RWMutex
+	publisher partybus.Publisher
+)
+
+func SetPublisher(p partybus.Publisher) {
+	lock.Lock()
+	defer lock.Unlock()
+	publisher = p
+}
+
+func Publish(event partybus.Event) {
+	lock.RLock()
+	p := publisher
+	lock.RUnlock()
+
+	if p != nil {
+		p.Publish(event)
+	}
+}
@@ -2,4 +2,6 @@ package internal
 
 const (
 	ApplicationName = "minicat"
+
+	JSONSchemaVersion = "1.0.0"
 )
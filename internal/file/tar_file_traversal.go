@@ -1,6 +1,7 @@
 package file
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -9,7 +10,9 @@ import (
 	"github.com/mholt/archiver/v3"
 )
 
-func ExtractGlobsFromTarToUniqueTempFile(archivePath, dir string, globs ...string) (map[string]Opener, error) {
+// ExtractGlobsFromTarToUniqueTempFile extracts the files of a tar archive matching any of the given globs, leaving out
+// the ones the admit function (when given) rejects, and stopping between files once the context is done.
+func ExtractGlobsFromTarToUniqueTempFile(ctx context.Context, archivePath, dir string, admit func(name string, info os.FileInfo) bool, globs ...string) (map[string]Opener, error) {
 	results := make(map[string]Opener)
 
 	if len(globs) == 0 {
@@ -19,6 +22,10 @@ func ExtractGlobsFromTarToUniqueTempFile(archivePath, dir string, globs ...strin
 	visitor := func(file archiver.File) error {
 		defer file.Close()
 
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
 		if file.FileInfo.IsDir() {
 			return nil
 		}
@@ -27,6 +34,10 @@ func ExtractGlobsFromTarToUniqueTempFile(archivePath, dir string, globs ...strin
 			return nil
 		}
 
+		if admit != nil && !admit(file.Name(), file.FileInfo) {
+			return nil
+		}
+
 		tempfilePrefix := filepath.Base(filepath.Clean(file.Name())) + "-"
 		tempFile, err := os.CreateTemp(dir, tempfilePrefix)
 		if err != nil {
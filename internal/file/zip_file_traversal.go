@@ -3,6 +3,7 @@ package file
 import (
 	"archive/zip"
 	"bytes"
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -67,7 +68,9 @@ func TraverseFilesInZip(archivePath string, visitor func(*zip.File) error, paths
 	return nil
 }
 
-func ExtractFromZipToUniqueTempFile(archivePath, dir string, paths ...string) (map[string]Opener, error) {
+// ExtractFromZipToUniqueTempFile extracts the given files of a zip archive, stopping between files once the context
+// is done.
+func ExtractFromZipToUniqueTempFile(ctx context.Context, archivePath, dir string, paths ...string) (map[string]Opener, error) {
 	results := make(map[string]Opener)
 
 	if len(paths) == 0 {
@@ -75,6 +78,10 @@ func ExtractFromZipToUniqueTempFile(archivePath, dir string, paths ...string) (m
 	}
 
 	visitor := func(file *zip.File) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
 		tempfilePrefix := filepath.Base(filepath.Clean(file.Name)) + "-"
 
 		tempFile, err := os.CreateTemp(dir, tempfilePrefix)
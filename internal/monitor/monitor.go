@@ -0,0 +1,78 @@
+package monitor
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/wagoodman/go-progress"
+)
+
+var (
+	_ progress.Progressable = (*Counter)(nil)
+	_ progress.Stager       = (*Stage)(nil)
+)
+
+// Counter is a progress.Progressable that catalogers update while the consumers of the published monitors read it
+// from other goroutines.
+type Counter struct {
+	n     int64
+	total int64
+	mu    sync.RWMutex
+	err   error
+}
+
+// NewCounter creates a counter of the given size, which is unknown when zero.
+func NewCounter(total int64) *Counter {
+	return &Counter{total: total}
+}
+
+func (c *Counter) Add(n int64) {
+	atomic.AddInt64(&c.n, n)
+}
+
+func (c *Counter) Current() int64 {
+	return atomic.LoadInt64(&c.n)
+}
+
+func (c *Counter) Size() int64 {
+	return atomic.LoadInt64(&c.total)
+}
+
+func (c *Counter) Error() error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.err
+}
+
+func (c *Counter) SetError(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.err = err
+}
+
+// SetCompleted marks the counter as completed, its size becoming its count when the size was unknown.
+func (c *Counter) SetCompleted() {
+	if n := c.Current(); n > 0 && c.Size() <= 0 {
+		atomic.StoreInt64(&c.total, n)
+	}
+	c.SetError(progress.ErrCompleted)
+}
+
+// Stage is a progress.Stager that catalogers update while the consumers of the published monitors read it from other
+// goroutines.
+type Stage struct {
+	mu      sync.RWMutex
+	current string
+}
+
+func (s *Stage) Set(current string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.current = current
+}
+
+func (s *Stage) Stage() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.current
+}
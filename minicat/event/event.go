@@ -0,0 +1,32 @@
+/*
+Package event provides the types of all events published onto the event bus while cataloging. For every event type
+defined here there is a corresponding parser in the parsers child package.
+*/
+package event
+
+import "github.com/wagoodman/go-partybus"
+
+const (
+	// PackageCatalogerStarted occurs when package cataloging begins; the source is the file resolver cataloged and the
+	// value is a cataloger.Monitor tracking the files processed and packages discovered across all catalogers.
+	PackageCatalogerStarted partybus.EventType = "minicat-package-cataloger-started-event"
+
+	// CatalogerStarted occurs when a single package cataloger begins; the source is the cataloger name.
+	CatalogerStarted partybus.EventType = "minicat-cataloger-started-event"
+
+	// CatalogerFinished occurs when a single package cataloger ends; the source is the cataloger name, the value is the
+	// number of packages discovered and the error is set if the cataloger failed or was cancelled.
+	CatalogerFinished partybus.EventType = "minicat-cataloger-finished-event"
+
+	// SecretsCatalogerStarted occurs when the secrets cataloger begins; the source is the file resolver cataloged and
+	// the value is a file.SecretsMonitor.
+	SecretsCatalogerStarted partybus.EventType = "minicat-secrets-cataloger-started-event"
+
+	// JavaArchiveLimitExceeded occurs when part of a Java archive is left out of the scan for exceeding the archive
+	// limits; the source is the virtual path of the archive and the value is a string describing what was left out.
+	JavaArchiveLimitExceeded partybus.EventType = "minicat-java-archive-limit-exceeded-event"
+
+	// FileDigestsCatalogerStarted occurs when the file digests cataloger begins; the source is the file resolver
+	// cataloged and the value is a progress.StagedProgressable.
+	FileDigestsCatalogerStarted partybus.EventType = "minicat-file-digests-cataloger-started-event"
+)
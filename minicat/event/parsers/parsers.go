@@ -0,0 +1,133 @@
+/*
+Package parsers provides parser helpers to extract payloads for each event type that the library publishes onto the
+event bus.
+*/
+package parsers
+
+import (
+	"fmt"
+
+	"github.com/wagoodman/go-partybus"
+	"github.com/wagoodman/go-progress"
+
+	"github.com/lovewebshell/minicat/minicat/event"
+	"github.com/lovewebshell/minicat/minicat/file"
+	"github.com/lovewebshell/minicat/minicat/pkg/cataloger"
+)
+
+type ErrBadPayload struct {
+	Type  partybus.EventType
+	Field string
+	Value interface{}
+}
+
+func (e *ErrBadPayload) Error() string {
+	return fmt.Sprintf("event='%s' has bad event payload field='%v': '%+v'", string(e.Type), e.Field, e.Value)
+}
+
+func newPayloadErr(t partybus.EventType, field string, value interface{}) error {
+	return &ErrBadPayload{
+		Type:  t,
+		Field: field,
+		Value: value,
+	}
+}
+
+func checkEventType(actual, expected partybus.EventType) error {
+	if actual != expected {
+		return newPayloadErr(expected, "Type", actual)
+	}
+	return nil
+}
+
+func ParsePackageCatalogerStarted(e partybus.Event) (*cataloger.Monitor, error) {
+	if err := checkEventType(e.Type, event.PackageCatalogerStarted); err != nil {
+		return nil, err
+	}
+
+	monitor, ok := e.Value.(cataloger.Monitor)
+	if !ok {
+		return nil, newPayloadErr(e.Type, "Value", e.Value)
+	}
+
+	return &monitor, nil
+}
+
+func ParseCatalogerStarted(e partybus.Event) (string, error) {
+	if err := checkEventType(e.Type, event.CatalogerStarted); err != nil {
+		return "", err
+	}
+
+	name, ok := e.Source.(string)
+	if !ok {
+		return "", newPayloadErr(e.Type, "Source", e.Source)
+	}
+
+	return name, nil
+}
+
+// ParseCatalogerFinished returns the cataloger name and the number of packages it discovered. The error returned only
+// describes a malformed event, the failure of the cataloger itself (if any) is carried in the event Error field.
+func ParseCatalogerFinished(e partybus.Event) (string, int, error) {
+	if err := checkEventType(e.Type, event.CatalogerFinished); err != nil {
+		return "", 0, err
+	}
+
+	name, ok := e.Source.(string)
+	if !ok {
+		return "", 0, newPayloadErr(e.Type, "Source", e.Source)
+	}
+
+	packages, ok := e.Value.(int)
+	if !ok {
+		return "", 0, newPayloadErr(e.Type, "Value", e.Value)
+	}
+
+	return name, packages, nil
+}
+
+func ParseSecretsCatalogingStarted(e partybus.Event) (*file.SecretsMonitor, error) {
+	if err := checkEventType(e.Type, event.SecretsCatalogerStarted); err != nil {
+		return nil, err
+	}
+
+	monitor, ok := e.Value.(file.SecretsMonitor)
+	if !ok {
+		return nil, newPayloadErr(e.Type, "Value", e.Value)
+	}
+
+	return &monitor, nil
+}
+
+// ParseJavaArchiveLimitExceeded returns the virtual path of the Java archive and the description of what was left out
+// of its scan.
+func ParseJavaArchiveLimitExceeded(e partybus.Event) (string, string, error) {
+	if err := checkEventType(e.Type, event.JavaArchiveLimitExceeded); err != nil {
+		return "", "", err
+	}
+
+	virtualPath, ok := e.Source.(string)
+	if !ok {
+		return "", "", newPayloadErr(e.Type, "Source", e.Source)
+	}
+
+	warning, ok := e.Value.(string)
+	if !ok {
+		return "", "", newPayloadErr(e.Type, "Value", e.Value)
+	}
+
+	return virtualPath, warning, nil
+}
+
+func ParseFileDigestsCatalogingStarted(e partybus.Event) (progress.StagedProgressable, error) {
+	if err := checkEventType(e.Type, event.FileDigestsCatalogerStarted); err != nil {
+		return nil, err
+	}
+
+	prog, ok := e.Value.(progress.StagedProgressable)
+	if !ok {
+		return nil, newPayloadErr(e.Type, "Value", e.Value)
+	}
+
+	return prog, nil
+}
@@ -1,12 +1,16 @@
 package file
 
 import (
+	"context"
+
 	"github.com/lovewebshell/minicat/internal/log"
 	"github.com/lovewebshell/minicat/minicat/source"
 )
 
-func allRegularFiles(resolver source.FileResolver) (locations []source.Location) {
-	for location := range resolver.AllLocations() {
+// allRegularFiles lists the regular files of the resolver, failing with the context error when the listing was cut
+// short by the context.
+func allRegularFiles(ctx context.Context, resolver source.FileResolver) (locations []source.Location, err error) {
+	for location := range resolver.AllLocations(ctx) {
 		resolvedLocations, err := resolver.FilesByPath(location.RealPath)
 		if err != nil {
 			log.Warnf("unable to resolve %+v: %+v", location, err)
@@ -26,5 +30,8 @@ func allRegularFiles(resolver source.FileResolver) (locations []source.Location)
 			locations = append(locations, resolvedLocation)
 		}
 	}
-	return locations
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return locations, nil
 }
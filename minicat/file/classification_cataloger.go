@@ -1,6 +1,8 @@
 package file
 
 import (
+	"context"
+
 	"github.com/lovewebshell/minicat/internal/log"
 	"github.com/lovewebshell/minicat/minicat/source"
 )
@@ -15,11 +17,19 @@ func NewClassificationCataloger(classifiers []Classifier) (*ClassificationCatalo
 	}, nil
 }
 
-func (i *ClassificationCataloger) Catalog(resolver source.FileResolver) (map[source.Coordinates][]Classification, error) {
+func (i *ClassificationCataloger) Catalog(ctx context.Context, resolver source.FileResolver) (map[source.Coordinates][]Classification, error) {
 	results := make(map[source.Coordinates][]Classification)
 
+	locations, err := allRegularFiles(ctx, resolver)
+	if err != nil {
+		return nil, err
+	}
+
 	numResults := 0
-	for _, location := range allRegularFiles(resolver) {
+	for _, location := range locations {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		for _, classifier := range i.classifiers {
 			result, err := classifier.Classify(resolver, location)
 			if err != nil {
@@ -2,6 +2,7 @@ package file
 
 import (
 	"bytes"
+	"context"
 	"encoding/base64"
 	"fmt"
 	"io"
@@ -23,15 +24,18 @@ func NewContentsCataloger(globs []string, skipFilesAboveSize int64) (*ContentsCa
 	}, nil
 }
 
-func (i *ContentsCataloger) Catalog(resolver source.FileResolver) (map[source.Coordinates]string, error) {
+func (i *ContentsCataloger) Catalog(ctx context.Context, resolver source.FileResolver) (map[source.Coordinates]string, error) {
 	results := make(map[source.Coordinates]string)
 	var locations []source.Location
 
-	locations, err := resolver.FilesByGlob(i.globs...)
+	locations, err := resolver.FilesByGlob(ctx, i.globs...)
 	if err != nil {
 		return nil, err
 	}
 	for _, location := range locations {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		metadata, err := resolver.FileMetadataByLocation(location)
 		if err != nil {
 			return nil, err
@@ -1,6 +1,7 @@
 package file
 
 import (
+	"context"
 	"crypto"
 	"errors"
 	"fmt"
@@ -8,8 +9,14 @@ import (
 	"io"
 	"strings"
 
+	"github.com/wagoodman/go-partybus"
+	"github.com/wagoodman/go-progress"
+
 	"github.com/lovewebshell/minicat/internal"
+	"github.com/lovewebshell/minicat/internal/bus"
 	"github.com/lovewebshell/minicat/internal/log"
+	"github.com/lovewebshell/minicat/internal/monitor"
+	"github.com/lovewebshell/minicat/minicat/event"
 	"github.com/lovewebshell/minicat/minicat/source"
 )
 
@@ -25,13 +32,23 @@ func NewDigestsCataloger(hashes []crypto.Hash) (*DigestsCataloger, error) {
 	}, nil
 }
 
-func (i *DigestsCataloger) Catalog(resolver source.FileResolver) (map[source.Coordinates][]Digest, error) {
+func (i *DigestsCataloger) Catalog(ctx context.Context, resolver source.FileResolver) (map[source.Coordinates][]Digest, error) {
 	results := make(map[source.Coordinates][]Digest)
-	locations := allRegularFiles(resolver)
+	locations, err := allRegularFiles(ctx, resolver)
+	if err != nil {
+		return nil, err
+	}
+	stage, prog := digestsCatalogingProgress(resolver, int64(len(locations)))
 
 	for _, location := range locations {
+		if err := ctx.Err(); err != nil {
+			prog.SetError(err)
+			return nil, err
+		}
 
+		stage.Set(location.RealPath)
 		result, err := i.catalogLocation(resolver, location)
+		prog.Add(1)
 
 		if errors.Is(err, errUndigestableFile) {
 			continue
@@ -43,12 +60,17 @@ func (i *DigestsCataloger) Catalog(resolver source.FileResolver) (map[source.Coo
 		}
 
 		if err != nil {
+			prog.SetError(err)
 			return nil, err
 		}
 
 		results[location.Coordinates] = result
 	}
 
+	log.Debugf("file digests cataloger processed %d files", prog.Current())
+	stage.Set("")
+	prog.SetCompleted()
+
 	return results, nil
 }
 
@@ -114,3 +136,22 @@ func CleanDigestAlgorithmName(name string) string {
 	lower := strings.ToLower(name)
 	return strings.ReplaceAll(lower, "-", "")
 }
+
+func digestsCatalogingProgress(resolver source.FileResolver, locations int64) (*monitor.Stage, *monitor.Counter) {
+	stage := &monitor.Stage{}
+	prog := monitor.NewCounter(locations)
+
+	bus.Publish(partybus.Event{
+		Type:   event.FileDigestsCatalogerStarted,
+		Source: resolver,
+		Value: struct {
+			progress.Stager
+			progress.Progressable
+		}{
+			Stager:       stage,
+			Progressable: prog,
+		},
+	})
+
+	return stage, prog
+}
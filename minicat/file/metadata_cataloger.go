@@ -1,6 +1,8 @@
 package file
 
 import (
+	"context"
+
 	"github.com/lovewebshell/minicat/minicat/source"
 )
 
@@ -11,14 +13,20 @@ func NewMetadataCataloger() *MetadataCataloger {
 	return &MetadataCataloger{}
 }
 
-func (i *MetadataCataloger) Catalog(resolver source.FileResolver) (map[source.Coordinates]source.FileMetadata, error) {
+func (i *MetadataCataloger) Catalog(ctx context.Context, resolver source.FileResolver) (map[source.Coordinates]source.FileMetadata, error) {
 	results := make(map[source.Coordinates]source.FileMetadata)
 	var locations []source.Location
-	for location := range resolver.AllLocations() {
+	for location := range resolver.AllLocations(ctx) {
 		locations = append(locations, location)
 	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 
 	for _, location := range locations {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		metadata, err := resolver.FileMetadataByLocation(location)
 		if err != nil {
 			return nil, err
@@ -2,15 +2,20 @@ package file
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"regexp"
 	"sort"
 
+	"github.com/wagoodman/go-partybus"
 	"github.com/wagoodman/go-progress"
 
 	"github.com/lovewebshell/minicat/internal"
+	"github.com/lovewebshell/minicat/internal/bus"
 	"github.com/lovewebshell/minicat/internal/log"
+	"github.com/lovewebshell/minicat/internal/monitor"
+	"github.com/lovewebshell/minicat/minicat/event"
 	"github.com/lovewebshell/minicat/minicat/source"
 )
 
@@ -36,28 +41,44 @@ func NewSecretsCataloger(patterns map[string]*regexp.Regexp, revealValues bool,
 	}, nil
 }
 
-func (i *SecretsCataloger) Catalog(resolver source.FileResolver) (map[source.Coordinates][]SearchResult, error) {
+func (i *SecretsCataloger) Catalog(ctx context.Context, resolver source.FileResolver) (map[source.Coordinates][]SearchResult, error) {
 	results := make(map[source.Coordinates][]SearchResult)
-	locations := allRegularFiles(resolver)
+	locations, err := allRegularFiles(ctx, resolver)
+	if err != nil {
+		return nil, err
+	}
+	stage, prog, secretsDiscovered := secretsCatalogingProgress(resolver, int64(len(locations)))
 
 	for _, location := range locations {
+		if err := ctx.Err(); err != nil {
+			prog.SetError(err)
+			return nil, err
+		}
 
+		stage.Set(location.RealPath)
 		result, err := i.catalogLocation(resolver, location)
 		if internal.IsErrPathPermission(err) {
 			log.Debugf("secrets cataloger skipping - %+v", err)
+			prog.Add(1)
 			continue
 		}
 
 		if err != nil {
+			prog.SetError(err)
 			return nil, err
 		}
 		if len(result) > 0 {
-
+			secretsDiscovered.Add(int64(len(result)))
 			results[location.Coordinates] = result
 		}
-
+		prog.Add(1)
 	}
 
+	log.Debugf("secrets cataloger discovered %d secrets", secretsDiscovered.Current())
+	stage.Set("")
+	prog.SetCompleted()
+	secretsDiscovered.SetCompleted()
+
 	return results, nil
 }
 
@@ -129,3 +150,21 @@ type SecretsMonitor struct {
 	SecretsDiscovered progress.Monitorable
 	progress.Progressable
 }
+
+func secretsCatalogingProgress(resolver source.FileResolver, locations int64) (*monitor.Stage, *monitor.Counter, *monitor.Counter) {
+	stage := &monitor.Stage{}
+	secretsDiscovered := monitor.NewCounter(0)
+	prog := monitor.NewCounter(locations)
+
+	bus.Publish(partybus.Event{
+		Type:   event.SecretsCatalogerStarted,
+		Source: resolver,
+		Value: SecretsMonitor{
+			Stager:            stage,
+			SecretsDiscovered: secretsDiscovered,
+			Progressable:      prog,
+		},
+	})
+
+	return stage, prog, secretsDiscovered
+}
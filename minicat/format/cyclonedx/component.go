@@ -0,0 +1,254 @@
+package cyclonedx
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+
+	"github.com/lovewebshell/minicat/internal/log"
+	"github.com/lovewebshell/minicat/minicat/file"
+	"github.com/lovewebshell/minicat/minicat/pkg"
+	"github.com/lovewebshell/minicat/minicat/source"
+)
+
+var (
+	packagePropertyPrefix  = propertyName(propertyPrefix, "package")
+	metadataPropertyPrefix = propertyName(propertyPrefix, "metadata")
+	locationPropertyPrefix = propertyName(propertyPrefix, "location")
+	cpePropertyName        = propertyName(propertyPrefix, "cpe23")
+)
+
+func encodeComponent(p pkg.Package) cdx.Component {
+	properties := encodeProperties(p, packagePropertyPrefix)
+	properties = append(properties, encodeLocations(p.Locations.ToSlice())...)
+	properties = append(properties, encodeProperties(p.Metadata, metadataPropertyPrefix)...)
+
+	var cpe string
+	for i, c := range p.CPEs {
+		if i == 0 {
+			cpe = pkg.CPEString(c)
+			continue
+		}
+		properties = append(properties, cdx.Property{
+			Name:  cpePropertyName,
+			Value: pkg.CPEString(c),
+		})
+	}
+
+	component := cdx.Component{
+		BOMRef:     string(p.ID()),
+		Type:       cdx.ComponentTypeLibrary,
+		Name:       p.Name,
+		Group:      p.GroupName,
+		Version:    p.Version,
+		PackageURL: p.PURL,
+		CPE:        cpe,
+		Licenses:   encodeLicenses(p.Licenses),
+		Hashes:     encodeHashes(p),
+	}
+
+	if len(properties) > 0 {
+		component.Properties = &properties
+	}
+
+	return component
+}
+
+func encodeLocations(locations []source.Location) (properties []cdx.Property) {
+	for i, l := range locations {
+		properties = append(properties, encodeProperties(l, propertyName(locationPropertyPrefix, strconv.Itoa(i)))...)
+		if l.VirtualPath != "" && l.VirtualPath != l.RealPath {
+			properties = append(properties, cdx.Property{
+				Name:  propertyName(locationPropertyPrefix, strconv.Itoa(i), "virtualPath"),
+				Value: l.VirtualPath,
+			})
+		}
+	}
+	return properties
+}
+
+func encodeLicenses(licenses []string) *cdx.Licenses {
+	if len(licenses) == 0 {
+		return nil
+	}
+	var result cdx.Licenses
+	for _, l := range licenses {
+		if strings.Contains(l, " OR ") || strings.Contains(l, " AND ") || strings.Contains(l, " WITH ") {
+			result = append(result, cdx.LicenseChoice{Expression: l})
+			continue
+		}
+		result = append(result, cdx.LicenseChoice{
+			License: &cdx.License{Name: l},
+		})
+	}
+	return &result
+}
+
+func encodeHashes(p pkg.Package) *[]cdx.Hash {
+	var digests []file.Digest
+	switch m := p.Metadata.(type) {
+	case pkg.JavaMetadata:
+		digests = m.ArchiveDigests
+	}
+
+	var hashes []cdx.Hash
+	for _, d := range digests {
+		algorithm := toHashAlgorithm(d.Algorithm)
+		if algorithm == "" {
+			log.Debugf("unable to express %q digest of package=%q in cyclonedx", d.Algorithm, p.Name)
+			continue
+		}
+		hashes = append(hashes, cdx.Hash{
+			Algorithm: algorithm,
+			Value:     d.Value,
+		})
+	}
+	if len(hashes) == 0 {
+		return nil
+	}
+	return &hashes
+}
+
+func toHashAlgorithm(algorithm string) cdx.HashAlgorithm {
+	switch strings.ToLower(strings.ReplaceAll(algorithm, "-", "")) {
+	case "md5":
+		return cdx.HashAlgoMD5
+	case "sha1":
+		return cdx.HashAlgoSHA1
+	case "sha256":
+		return cdx.HashAlgoSHA256
+	case "sha384":
+		return cdx.HashAlgoSHA384
+	case "sha512":
+		return cdx.HashAlgoSHA512
+	}
+	return ""
+}
+
+func decodeComponent(c cdx.Component) *pkg.Package {
+	var properties []cdx.Property
+	if c.Properties != nil {
+		properties = *c.Properties
+	}
+
+	p := &pkg.Package{
+		Name:      c.Name,
+		Version:   c.Version,
+		GroupName: c.Group,
+		PURL:      c.PackageURL,
+		Licenses:  decodeLicenses(c.Licenses),
+		CPEs:      decodeCPEs(c.CPE, properties),
+		Locations: source.NewLocationSet(decodeLocations(properties)...),
+	}
+
+	decodeProperties(p, properties, packagePropertyPrefix)
+
+	if p.Type == "" {
+		p.Type = pkg.TypeFromPURL(p.PURL)
+	}
+	if p.Language == "" {
+		p.Language = pkg.LanguageFromPURL(p.PURL)
+	}
+
+	p.Metadata = decodeMetadata(p.MetadataType, properties)
+	if p.Metadata == nil {
+		p.MetadataType = ""
+	}
+
+	if m, ok := p.Metadata.(pkg.JavaMetadata); ok {
+		m.PURL = p.PURL
+		m.ArchiveDigests = decodeHashes(c.Hashes)
+		p.Metadata = m
+	}
+
+	p.SetID()
+
+	return p
+}
+
+func decodeMetadata(ty pkg.MetadataType, properties []cdx.Property) interface{} {
+	if ty == "" {
+		return nil
+	}
+	metadataType, ok := pkg.MetadataTypeByName[ty]
+	if !ok {
+		log.Warnf("unsupported package metadata type: %q", ty)
+		return nil
+	}
+	metadata := reflect.New(metadataType)
+	decodeProperties(metadata.Interface(), properties, metadataPropertyPrefix)
+	return metadata.Elem().Interface()
+}
+
+func decodeLocations(properties []cdx.Property) (locations []source.Location) {
+	values := make(map[string]string)
+	for _, p := range properties {
+		values[p.Name] = p.Value
+	}
+
+	for i := 0; ; i++ {
+		prefix := propertyName(locationPropertyPrefix, strconv.Itoa(i))
+		if !hasPropertiesWithPrefix(values, prefix) {
+			break
+		}
+		var l source.Location
+		decodeProperties(&l, properties, prefix)
+		l.VirtualPath = values[propertyName(prefix, "virtualPath")]
+		locations = append(locations, l)
+	}
+	return locations
+}
+
+func decodeCPEs(first string, properties []cdx.Property) (cpes []pkg.CPE) {
+	candidates := []string{first}
+	for _, p := range properties {
+		if p.Name == cpePropertyName {
+			candidates = append(candidates, p.Value)
+		}
+	}
+
+	for _, candidate := range candidates {
+		if candidate == "" {
+			continue
+		}
+		c, err := pkg.NewCPE(candidate)
+		if err != nil {
+			log.Warnf("excluding invalid CPE %q: %v", candidate, err)
+			continue
+		}
+		cpes = append(cpes, c)
+	}
+	return cpes
+}
+
+func decodeLicenses(licenses *cdx.Licenses) (result []string) {
+	if licenses == nil {
+		return nil
+	}
+	for _, l := range *licenses {
+		switch {
+		case l.Expression != "":
+			result = append(result, l.Expression)
+		case l.License != nil && l.License.ID != "":
+			result = append(result, l.License.ID)
+		case l.License != nil && l.License.Name != "":
+			result = append(result, l.License.Name)
+		}
+	}
+	return result
+}
+
+func decodeHashes(hashes *[]cdx.Hash) (digests []file.Digest) {
+	if hashes == nil {
+		return nil
+	}
+	for _, h := range *hashes {
+		digests = append(digests, file.Digest{
+			Algorithm: strings.ToLower(strings.ReplaceAll(string(h.Algorithm), "-", "")),
+			Value:     h.Value,
+		})
+	}
+	return digests
+}
@@ -0,0 +1,86 @@
+package cyclonedx
+
+import (
+	cdx "github.com/CycloneDX/cyclonedx-go"
+
+	"github.com/lovewebshell/minicat/internal/log"
+	"github.com/lovewebshell/minicat/minicat/artifact"
+	"github.com/lovewebshell/minicat/minicat/linux"
+)
+
+var distroPropertyPrefix = propertyName(propertyPrefix, "distro")
+
+func encodeDistro(release *linux.Release) *cdx.Component {
+	if release == nil {
+		return nil
+	}
+
+	id, err := artifact.IDByHash(*release)
+	if err != nil {
+		log.Warnf("unable to get fingerprint of linux release=%q: %+v", release.String(), err)
+	}
+
+	var references []cdx.ExternalReference
+	for _, ref := range []struct {
+		url string
+		ty  cdx.ExternalReferenceType
+	}{
+		{release.HomeURL, cdx.ERTypeWebsite},
+		{release.SupportURL, cdx.ERTypeSupport},
+		{release.BugReportURL, cdx.ERTypeIssueTracker},
+		{release.PrivacyPolicyURL, cdx.ERTypeOther},
+	} {
+		if ref.url != "" {
+			references = append(references, cdx.ExternalReference{URL: ref.url, Type: ref.ty})
+		}
+	}
+
+	component := &cdx.Component{
+		BOMRef:      string(id),
+		Type:        cdx.ComponentTypeOS,
+		Name:        release.ID,
+		Version:     release.VersionID,
+		Description: release.PrettyName,
+		CPE:         release.CPEName,
+	}
+
+	if len(references) > 0 {
+		component.ExternalReferences = &references
+	}
+
+	if properties := encodeProperties(*release, distroPropertyPrefix); len(properties) > 0 {
+		component.Properties = &properties
+	}
+
+	return component
+}
+
+func decodeDistro(c cdx.Component) *linux.Release {
+	release := &linux.Release{
+		ID:         c.Name,
+		VersionID:  c.Version,
+		PrettyName: c.Description,
+		CPEName:    c.CPE,
+	}
+
+	if c.Properties != nil {
+		decodeProperties(release, *c.Properties, distroPropertyPrefix)
+	}
+
+	if c.ExternalReferences != nil {
+		for _, ref := range *c.ExternalReferences {
+			switch ref.Type {
+			case cdx.ERTypeWebsite:
+				release.HomeURL = ref.URL
+			case cdx.ERTypeSupport:
+				release.SupportURL = ref.URL
+			case cdx.ERTypeIssueTracker:
+				release.BugReportURL = ref.URL
+			case cdx.ERTypeOther:
+				release.PrivacyPolicyURL = ref.URL
+			}
+		}
+	}
+
+	return release
+}
@@ -0,0 +1,56 @@
+/*
+Package cyclonedx provides CycloneDX (1.4) JSON and XML SBOM encoders and decoders. Package fields and metadata fields
+carrying a `cyclonedx` struct tag are flattened into component properties so they survive a round trip.
+*/
+package cyclonedx
+
+import (
+	"fmt"
+	"io"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+
+	"github.com/lovewebshell/minicat/minicat/sbom"
+)
+
+const (
+	JSONID = "cyclonedx-json"
+	XMLID  = "cyclonedx-xml"
+)
+
+func EncodeJSON(output io.Writer, s sbom.SBOM) error {
+	return encode(output, s, cdx.BOMFileFormatJSON)
+}
+
+func EncodeXML(output io.Writer, s sbom.SBOM) error {
+	return encode(output, s, cdx.BOMFileFormatXML)
+}
+
+func DecodeJSON(reader io.Reader) (*sbom.SBOM, error) {
+	return decode(reader, cdx.BOMFileFormatJSON)
+}
+
+func DecodeXML(reader io.Reader) (*sbom.SBOM, error) {
+	return decode(reader, cdx.BOMFileFormatXML)
+}
+
+func encode(output io.Writer, s sbom.SBOM, format cdx.BOMFileFormat) error {
+	bom := ToFormatModel(s)
+	enc := cdx.NewBOMEncoder(output, format)
+	enc.SetPretty(true)
+
+	return enc.Encode(bom)
+}
+
+func decode(reader io.Reader, format cdx.BOMFileFormat) (*sbom.SBOM, error) {
+	bom := &cdx.BOM{}
+	if err := cdx.NewBOMDecoder(reader, format).Decode(bom); err != nil {
+		return nil, fmt.Errorf("unable to decode cyclonedx document: %w", err)
+	}
+
+	if format == cdx.BOMFileFormatJSON && bom.BOMFormat != "CycloneDX" {
+		return nil, fmt.Errorf("not a cyclonedx document: bomFormat=%q", bom.BOMFormat)
+	}
+
+	return ToMinicatModel(bom)
+}
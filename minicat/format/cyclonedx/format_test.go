@@ -0,0 +1,79 @@
+package cyclonedx
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lovewebshell/minicat/minicat/pkg"
+	"github.com/lovewebshell/minicat/minicat/sbom"
+)
+
+func TestRoundTrip_Metadata(t *testing.T) {
+	packages := []pkg.Package{
+		{
+			Name:         "numpy",
+			Version:      "1.24.3",
+			Type:         pkg.CondaPkg,
+			MetadataType: pkg.CondaMetadataType,
+			Metadata: pkg.CondaMetadata{
+				Name:        "numpy",
+				Version:     "1.24.3",
+				Build:       "py311h08b1b3b_1",
+				BuildNumber: 1,
+				Channel:     "conda-forge",
+				Subdir:      "linux-64",
+				SHA256:      "aa2a4aee8a4b6ee7b6b3b1b8a9c6d1e5f7a8b9c0d1e2f3a4b5c6d7e8f9a0b1c2",
+				Depends:     []string{"libblas >=3.9.0", "python >=3.11,<3.12.0a0"},
+				Files:       []string{"/opt/conda/lib/python3.11/site-packages/numpy/__init__.py"},
+			},
+		},
+		{
+			Name:         "guava",
+			Version:      "31.1-jre",
+			Language:     pkg.Gradle,
+			Type:         pkg.JavaPkg,
+			MetadataType: pkg.GradleMetadataType,
+			Metadata: pkg.GradleMetadata{
+				GroupID:        "com.google.guava",
+				ArtifactID:     "guava",
+				Version:        "31.1-jre",
+				Configurations: []string{"compileClasspath", "runtimeClasspath"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		encode func(io.Writer, sbom.SBOM) error
+		decode func(io.Reader) (*sbom.SBOM, error)
+	}{
+		{name: "json", encode: EncodeJSON, decode: DecodeJSON},
+		{name: "xml", encode: EncodeXML, decode: DecodeXML},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := sbom.SBOM{
+				Artifacts: sbom.Artifacts{
+					PackageCatalog: pkg.NewCatalog(packages...),
+				},
+			}
+
+			var buf bytes.Buffer
+			require.NoError(t, test.encode(&buf, s))
+			decoded, err := test.decode(&buf)
+			require.NoError(t, err)
+
+			metadata := make(map[string]interface{})
+			for p := range decoded.Artifacts.PackageCatalog.Enumerate() {
+				metadata[p.Name] = p.Metadata
+			}
+			for _, p := range packages {
+				assert.Equal(t, p.Metadata, metadata[p.Name])
+			}
+		})
+	}
+}
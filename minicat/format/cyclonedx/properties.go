@@ -0,0 +1,259 @@
+package cyclonedx
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+const propertyPrefix = "minicat"
+
+func propertyName(parts ...string) string {
+	var nonEmpty []string
+	for _, p := range parts {
+		if p != "" {
+			nonEmpty = append(nonEmpty, p)
+		}
+	}
+	return strings.Join(nonEmpty, ":")
+}
+
+// encodeProperties flattens every field carrying a `cyclonedx` struct tag (recursively) into name/value pairs. Structs
+// without any `cyclonedx` tag have all of their fields flattened, named after their `json` tags.
+func encodeProperties(obj interface{}, prefix string) []cdx.Property {
+	values := make(map[string]string)
+	encodeValue(values, reflect.ValueOf(obj), prefix, false)
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var properties []cdx.Property
+	for _, name := range names {
+		properties = append(properties, cdx.Property{
+			Name:  name,
+			Value: values[name],
+		})
+	}
+	return properties
+}
+
+// propertyField tells the property name of a field of the given struct type, if the field is expressed as a property.
+func propertyField(t reflect.Type, f reflect.StructField) (string, bool) {
+	if hasCycloneDXTags(t) {
+		tag, ok := f.Tag.Lookup("cyclonedx")
+		return tag, ok && tag != "-"
+	}
+
+	tag, ok := f.Tag.Lookup("json")
+	if !ok {
+		return "", false
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	return name, name != "" && name != "-"
+}
+
+func hasCycloneDXTags(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		if _, ok := t.Field(i).Tag.Lookup("cyclonedx"); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func encodeValue(out map[string]string, v reflect.Value, name string, force bool) {
+	if !v.IsValid() {
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return
+		}
+		encodeValue(out, v.Elem(), name, true)
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if f.PkgPath != "" {
+				continue
+			}
+			tag, ok := propertyField(t, f)
+			if !ok {
+				continue
+			}
+			encodeValue(out, v.Field(i), propertyName(name, tag), false)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			encodeValue(out, v.Index(i), propertyName(name, strconv.Itoa(i)), true)
+		}
+	case reflect.Map:
+		for _, key := range v.MapKeys() {
+			encodeValue(out, v.MapIndex(key), propertyName(name, fmt.Sprintf("%v", key.Interface())), true)
+		}
+	case reflect.String:
+		if v.String() != "" || force {
+			out[name] = v.String()
+		}
+	case reflect.Bool:
+		out[name] = strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if v.Int() != 0 || force {
+			out[name] = strconv.FormatInt(v.Int(), 10)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if v.Uint() != 0 || force {
+			out[name] = strconv.FormatUint(v.Uint(), 10)
+		}
+	case reflect.Float32, reflect.Float64:
+		if v.Float() != 0 || force {
+			out[name] = strconv.FormatFloat(v.Float(), 'f', -1, 64)
+		}
+	}
+}
+
+// decodeProperties is the inverse of encodeProperties, populating the fields of the given struct pointer.
+func decodeProperties(obj interface{}, properties []cdx.Property, prefix string) {
+	values := make(map[string]string)
+	for _, p := range properties {
+		if _, exists := values[p.Name]; !exists {
+			values[p.Name] = p.Value
+		}
+	}
+	decodeValue(values, reflect.ValueOf(obj), prefix)
+}
+
+func hasPropertiesWithPrefix(values map[string]string, name string) bool {
+	for k := range values {
+		if k == name || strings.HasPrefix(k, name+":") {
+			return true
+		}
+	}
+	return false
+}
+
+func isCompositeType(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Struct, reflect.Slice, reflect.Array, reflect.Map:
+		return true
+	}
+	return false
+}
+
+func decodeValue(values map[string]string, v reflect.Value, name string) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if !hasPropertiesWithPrefix(values, name) {
+			return
+		}
+		if v.IsNil() {
+			if !v.CanSet() {
+				return
+			}
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		decodeValue(values, v.Elem(), name)
+		return
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if f.PkgPath != "" {
+				continue
+			}
+			tag, ok := propertyField(t, f)
+			if !ok {
+				continue
+			}
+			decodeValue(values, v.Field(i), propertyName(name, tag))
+		}
+		return
+	}
+
+	if !v.CanSet() {
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Slice:
+		var count int
+		for hasPropertiesWithPrefix(values, propertyName(name, strconv.Itoa(count))) {
+			count++
+		}
+		if count == 0 {
+			return
+		}
+		slice := reflect.MakeSlice(v.Type(), count, count)
+		for i := 0; i < count; i++ {
+			decodeValue(values, slice.Index(i), propertyName(name, strconv.Itoa(i)))
+		}
+		v.Set(slice)
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return
+		}
+		// the keys of a map holding scalars are the rest of the property names, while those of a map holding structs,
+		// slices or maps are only their first segment, the elements being decoded from what follows
+		composite := isCompositeType(v.Type().Elem())
+		keys := make(map[string]struct{})
+		for k := range values {
+			if !strings.HasPrefix(k, name+":") {
+				continue
+			}
+			key := strings.TrimPrefix(k, name+":")
+			if composite {
+				key, _, _ = strings.Cut(key, ":")
+			}
+			keys[key] = struct{}{}
+		}
+		m := reflect.MakeMap(v.Type())
+		for key := range keys {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			decodeValue(values, elem, propertyName(name, key))
+			m.SetMapIndex(reflect.ValueOf(key).Convert(v.Type().Key()), elem)
+		}
+		if m.Len() > 0 {
+			v.Set(m)
+		}
+	case reflect.String:
+		if value, ok := values[name]; ok {
+			v.SetString(value)
+		}
+	case reflect.Bool:
+		if value, ok := values[name]; ok {
+			if b, err := strconv.ParseBool(value); err == nil {
+				v.SetBool(b)
+			}
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if value, ok := values[name]; ok {
+			if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+				v.SetInt(i)
+			}
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if value, ok := values[name]; ok {
+			if i, err := strconv.ParseUint(value, 10, 64); err == nil {
+				v.SetUint(i)
+			}
+		}
+	case reflect.Float32, reflect.Float64:
+		if value, ok := values[name]; ok {
+			if f, err := strconv.ParseFloat(value, 64); err == nil {
+				v.SetFloat(f)
+			}
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package cyclonedx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProperties_MapRoundTrip(t *testing.T) {
+	type entry struct {
+		Version string   `json:"version"`
+		Extras  []string `json:"extras"`
+	}
+	type metadata struct {
+		Entries  map[string]entry             `json:"entries"`
+		Sections map[string]map[string]string `json:"sections"`
+		Settings map[string]string            `json:"settings"`
+	}
+
+	original := metadata{
+		Entries: map[string]entry{
+			"requests": {Version: "2.31.0", Extras: []string{"socks"}},
+			"urllib3":  {Version: "2.0.4"},
+		},
+		Sections: map[string]map[string]string{
+			"main": {"Name": "app", "Version": "1.0"},
+		},
+		Settings: map[string]string{
+			"vcs.revision": "abc123",
+			"url:scheme":   "https",
+		},
+	}
+
+	properties := encodeProperties(original, "minicat:metadata")
+
+	var decoded metadata
+	decodeProperties(&decoded, properties, "minicat:metadata")
+	assert.Equal(t, original, decoded)
+}
@@ -0,0 +1,147 @@
+package cyclonedx
+
+import (
+	"sort"
+	"time"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/google/uuid"
+
+	"github.com/lovewebshell/minicat/internal"
+	"github.com/lovewebshell/minicat/internal/log"
+	"github.com/lovewebshell/minicat/minicat/artifact"
+	"github.com/lovewebshell/minicat/minicat/pkg"
+	"github.com/lovewebshell/minicat/minicat/sbom"
+	"github.com/lovewebshell/minicat/minicat/source"
+)
+
+var sourcePropertyName = propertyName(propertyPrefix, "source", "scheme")
+
+func ToFormatModel(s sbom.SBOM) *cdx.BOM {
+	bom := cdx.NewBOM()
+	bom.SerialNumber = uuid.New().URN()
+	bom.Metadata = toBomMetadata(s.Source)
+
+	components := make([]cdx.Component, 0)
+	if s.Artifacts.PackageCatalog != nil {
+		for _, p := range s.Artifacts.PackageCatalog.Sorted() {
+			components = append(components, encodeComponent(p))
+		}
+	}
+
+	if distro := encodeDistro(s.Artifacts.LinuxDistribution); distro != nil {
+		components = append(components, *distro)
+	}
+
+	bom.Components = &components
+
+	if dependencies := toDependencies(s.Artifacts.PackageCatalog, s.Relationships); len(dependencies) > 0 {
+		bom.Dependencies = &dependencies
+	}
+
+	return bom
+}
+
+func toBomMetadata(src source.Metadata) *cdx.Metadata {
+	return &cdx.Metadata{
+		Timestamp: time.Now().Format(time.RFC3339),
+		Tools: &[]cdx.Tool{
+			{
+				Name: internal.ApplicationName,
+			},
+		},
+		Component: toBomSourceComponent(src),
+	}
+}
+
+func toBomSourceComponent(src source.Metadata) *cdx.Component {
+	var component *cdx.Component
+	switch src.Scheme {
+	case source.ImageScheme:
+		id, err := artifact.IDByHash(src.ImageMetadata.ID)
+		if err != nil {
+			log.Warnf("unable to get fingerprint of image metadata=%s: %+v", src.ImageMetadata.ID, err)
+		}
+		component = &cdx.Component{
+			BOMRef:  string(id),
+			Type:    cdx.ComponentTypeContainer,
+			Name:    src.ImageMetadata.UserInput,
+			Version: src.ImageMetadata.ManifestDigest,
+		}
+	case source.DirectoryScheme, source.FileScheme:
+		id, err := artifact.IDByHash(src.Path)
+		if err != nil {
+			log.Warnf("unable to get fingerprint of source path=%s: %+v", src.Path, err)
+		}
+		component = &cdx.Component{
+			BOMRef: string(id),
+			Type:   cdx.ComponentTypeFile,
+			Name:   src.Path,
+		}
+	default:
+		return nil
+	}
+
+	component.Properties = &[]cdx.Property{
+		{
+			Name:  sourcePropertyName,
+			Value: string(src.Scheme),
+		},
+	}
+	return component
+}
+
+func isDependencyRelationship(ty artifact.RelationshipType) bool {
+	switch ty {
+	case artifact.DependencyOfRelationship,
+		artifact.RuntimeDependencyOfRelationship,
+		artifact.DevDependencyOfRelationship,
+		artifact.BuildDependencyOfRelationship:
+		return true
+	}
+	return false
+}
+
+// toDependencies expresses package-to-package dependency relationships (child "dependency-of" parent) as the
+// cyclonedx dependency graph (parent "dependsOn" child).
+func toDependencies(catalog *pkg.Catalog, relationships []artifact.Relationship) []cdx.Dependency {
+	if catalog == nil {
+		return nil
+	}
+
+	dependsOn := make(map[string]*internal.StringSet)
+	for _, r := range relationships {
+		if !isDependencyRelationship(r.Type) || r.From == nil || r.To == nil {
+			continue
+		}
+
+		child, parent := r.From.ID(), r.To.ID()
+		if catalog.Package(child) == nil || catalog.Package(parent) == nil {
+			log.Debugf("unable to express %s relationship in cyclonedx, dropping: %+v", r.Type, r)
+			continue
+		}
+
+		refs, ok := dependsOn[string(parent)]
+		if !ok {
+			set := internal.NewStringSet()
+			refs = &set
+			dependsOn[string(parent)] = refs
+		}
+		refs.Add(string(child))
+	}
+
+	var result []cdx.Dependency
+	for parent, children := range dependsOn {
+		refs := children.ToSlice()
+		result = append(result, cdx.Dependency{
+			Ref:          parent,
+			Dependencies: &refs,
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Ref < result[j].Ref
+	})
+
+	return result
+}
@@ -0,0 +1,115 @@
+package cyclonedx
+
+import (
+	cdx "github.com/CycloneDX/cyclonedx-go"
+
+	"github.com/lovewebshell/minicat/internal/log"
+	"github.com/lovewebshell/minicat/minicat/artifact"
+	"github.com/lovewebshell/minicat/minicat/pkg"
+	"github.com/lovewebshell/minicat/minicat/sbom"
+	"github.com/lovewebshell/minicat/minicat/source"
+)
+
+func ToMinicatModel(bom *cdx.BOM) (*sbom.SBOM, error) {
+	s := &sbom.SBOM{
+		Artifacts: sbom.Artifacts{
+			PackageCatalog: pkg.NewCatalog(),
+		},
+		Source: toMinicatSourceMetadata(bom.Metadata),
+	}
+
+	packagesByRef := make(map[string]*pkg.Package)
+	if bom.Components != nil {
+		collectComponents(*bom.Components, s, packagesByRef)
+	}
+
+	if bom.Dependencies != nil {
+		s.Relationships = toMinicatRelationships(*bom.Dependencies, packagesByRef)
+	}
+
+	return s, nil
+}
+
+func collectComponents(components []cdx.Component, s *sbom.SBOM, packagesByRef map[string]*pkg.Package) {
+	for _, c := range components {
+		switch c.Type {
+		case cdx.ComponentTypeOS:
+			if s.Artifacts.LinuxDistribution == nil {
+				s.Artifacts.LinuxDistribution = decodeDistro(c)
+			}
+		case cdx.ComponentTypeLibrary, cdx.ComponentTypeFramework, cdx.ComponentTypeApplication:
+			p := decodeComponent(c)
+			if !pkg.IsValid(p) {
+				log.Debugf("skipping invalid cyclonedx component: %q", c.BOMRef)
+				break
+			}
+			s.Artifacts.PackageCatalog.Add(*p)
+			if c.BOMRef != "" {
+				packagesByRef[c.BOMRef] = p
+			}
+		}
+
+		if c.Components != nil {
+			collectComponents(*c.Components, s, packagesByRef)
+		}
+	}
+}
+
+func toMinicatRelationships(dependencies []cdx.Dependency, packagesByRef map[string]*pkg.Package) []artifact.Relationship {
+	var relationships []artifact.Relationship
+	for _, d := range dependencies {
+		parent, ok := packagesByRef[d.Ref]
+		if !ok || d.Dependencies == nil {
+			continue
+		}
+		for _, ref := range *d.Dependencies {
+			child, ok := packagesByRef[ref]
+			if !ok {
+				log.Debugf("cyclonedx dependency ref not found: %q", ref)
+				continue
+			}
+			relationships = append(relationships, artifact.Relationship{
+				From: *child,
+				To:   *parent,
+				Type: artifact.DependencyOfRelationship,
+			})
+		}
+	}
+	return relationships
+}
+
+func toMinicatSourceMetadata(metadata *cdx.Metadata) source.Metadata {
+	if metadata == nil || metadata.Component == nil {
+		return source.Metadata{Scheme: source.UnknownScheme}
+	}
+
+	c := metadata.Component
+	var scheme source.Scheme
+	if c.Properties != nil {
+		for _, p := range *c.Properties {
+			if p.Name == sourcePropertyName {
+				scheme = source.Scheme(p.Value)
+			}
+		}
+	}
+
+	switch c.Type {
+	case cdx.ComponentTypeContainer:
+		return source.Metadata{
+			Scheme: source.ImageScheme,
+			ImageMetadata: source.ImageMetadata{
+				UserInput:      c.Name,
+				ManifestDigest: c.Version,
+			},
+		}
+	case cdx.ComponentTypeFile:
+		if scheme != source.FileScheme {
+			scheme = source.DirectoryScheme
+		}
+		return source.Metadata{
+			Scheme: scheme,
+			Path:   c.Name,
+		}
+	}
+	return source.Metadata{Scheme: source.UnknownScheme}
+}
@@ -0,0 +1,45 @@
+/*
+Package json provides the native minicat JSON SBOM encoder and decoder, which preserves the full cataloging result
+(packages and their typed metadata, relationships, distro and source metadata) in a versioned schema.
+*/
+package json
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/lovewebshell/minicat/internal"
+	"github.com/lovewebshell/minicat/minicat/format/json/model"
+	"github.com/lovewebshell/minicat/minicat/sbom"
+)
+
+const ID = "minicat-json"
+
+func Encode(output io.Writer, s sbom.SBOM) error {
+	doc := ToFormatModel(s)
+
+	enc := json.NewEncoder(output)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", " ")
+
+	return enc.Encode(&doc)
+}
+
+func Decode(reader io.Reader) (*sbom.SBOM, error) {
+	var doc model.Document
+	dec := json.NewDecoder(reader)
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("unable to decode minicat-json: %w", err)
+	}
+
+	if doc.Schema.Version == "" {
+		return nil, fmt.Errorf("not a minicat-json document: missing schema version")
+	}
+
+	if doc.Schema.Version != internal.JSONSchemaVersion {
+		return nil, fmt.Errorf("unsupported minicat-json schema version: %q (expected %q)", doc.Schema.Version, internal.JSONSchemaVersion)
+	}
+
+	return toMinicatModel(doc)
+}
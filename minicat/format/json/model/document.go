@@ -0,0 +1,14 @@
+package model
+
+type Document struct {
+	Artifacts             []Package      `json:"artifacts"`
+	ArtifactRelationships []Relationship `json:"artifactRelationships"`
+	Files                 []File         `json:"files,omitempty"`
+	Source                Source         `json:"source"`
+	Distro                *LinuxRelease  `json:"distro,omitempty"`
+	Schema                Schema         `json:"schema"`
+}
+
+type Schema struct {
+	Version string `json:"version"`
+}
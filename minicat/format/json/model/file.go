@@ -0,0 +1,8 @@
+package model
+
+import "github.com/lovewebshell/minicat/minicat/source"
+
+type File struct {
+	ID       string             `json:"id"`
+	Location source.Coordinates `json:"location"`
+}
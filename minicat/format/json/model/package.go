@@ -0,0 +1,86 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/lovewebshell/minicat/minicat/pkg"
+	"github.com/lovewebshell/minicat/minicat/source"
+)
+
+type Package struct {
+	ID           string           `json:"id"`
+	Name         string           `json:"name"`
+	Version      string           `json:"version"`
+	Type         pkg.Type         `json:"type"`
+	FoundBy      string           `json:"foundBy"`
+	Locations    []Location       `json:"locations"`
+	Licenses     []string         `json:"licenses"`
+	Language     pkg.Language     `json:"language"`
+	CPEs         []string         `json:"cpes"`
+	PURL         string           `json:"purl"`
+	GroupName    string           `json:"groupName,omitempty"`
+	MetadataType pkg.MetadataType `json:"metadataType,omitempty"`
+	Metadata     interface{}      `json:"metadata,omitempty"`
+}
+
+type Location struct {
+	source.Coordinates
+	VirtualPath string `json:"virtualPath,omitempty"`
+}
+
+type packageWithRawMetadata struct {
+	ID           string           `json:"id"`
+	Name         string           `json:"name"`
+	Version      string           `json:"version"`
+	Type         pkg.Type         `json:"type"`
+	FoundBy      string           `json:"foundBy"`
+	Locations    []Location       `json:"locations"`
+	Licenses     []string         `json:"licenses"`
+	Language     pkg.Language     `json:"language"`
+	CPEs         []string         `json:"cpes"`
+	PURL         string           `json:"purl"`
+	GroupName    string           `json:"groupName,omitempty"`
+	MetadataType pkg.MetadataType `json:"metadataType,omitempty"`
+	Metadata     json.RawMessage  `json:"metadata,omitempty"`
+}
+
+func (p *Package) UnmarshalJSON(b []byte) error {
+	var unpacked packageWithRawMetadata
+	if err := json.Unmarshal(b, &unpacked); err != nil {
+		return err
+	}
+
+	*p = Package{
+		ID:           unpacked.ID,
+		Name:         unpacked.Name,
+		Version:      unpacked.Version,
+		Type:         unpacked.Type,
+		FoundBy:      unpacked.FoundBy,
+		Locations:    unpacked.Locations,
+		Licenses:     unpacked.Licenses,
+		Language:     unpacked.Language,
+		CPEs:         unpacked.CPEs,
+		PURL:         unpacked.PURL,
+		GroupName:    unpacked.GroupName,
+		MetadataType: unpacked.MetadataType,
+	}
+
+	if unpacked.MetadataType == "" || len(unpacked.Metadata) == 0 || string(unpacked.Metadata) == "null" {
+		return nil
+	}
+
+	metadataType, ok := pkg.MetadataTypeByName[unpacked.MetadataType]
+	if !ok {
+		return fmt.Errorf("unsupported package metadata type: %+v", unpacked.MetadataType)
+	}
+
+	metadata := reflect.New(metadataType).Interface()
+	if err := json.Unmarshal(unpacked.Metadata, metadata); err != nil {
+		return fmt.Errorf("unable to unmarshal %s metadata for package=%q: %w", unpacked.MetadataType, unpacked.Name, err)
+	}
+
+	p.Metadata = reflect.ValueOf(metadata).Elem().Interface()
+	return nil
+}
@@ -0,0 +1,35 @@
+package model
+
+import "encoding/json"
+
+type Relationship struct {
+	Parent   string      `json:"parent"`
+	Child    string      `json:"child"`
+	Type     string      `json:"type"`
+	Metadata interface{} `json:"metadata,omitempty"`
+}
+
+type relationshipWithRawMetadata struct {
+	Parent   string          `json:"parent"`
+	Child    string          `json:"child"`
+	Type     string          `json:"type"`
+	Metadata json.RawMessage `json:"metadata,omitempty"`
+}
+
+func (r *Relationship) UnmarshalJSON(b []byte) error {
+	var unpacked relationshipWithRawMetadata
+	if err := json.Unmarshal(b, &unpacked); err != nil {
+		return err
+	}
+
+	*r = Relationship{
+		Parent: unpacked.Parent,
+		Child:  unpacked.Child,
+		Type:   unpacked.Type,
+	}
+
+	if len(unpacked.Metadata) > 0 && string(unpacked.Metadata) != "null" {
+		r.Metadata = unpacked.Metadata
+	}
+	return nil
+}
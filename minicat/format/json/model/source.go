@@ -0,0 +1,52 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/lovewebshell/minicat/minicat/source"
+)
+
+const (
+	ImageSourceType     = "image"
+	DirectorySourceType = "directory"
+	FileSourceType      = "file"
+)
+
+type Source struct {
+	Type   string      `json:"type"`
+	Target interface{} `json:"target"`
+}
+
+type sourceUnpacker struct {
+	Type   string          `json:"type"`
+	Target json.RawMessage `json:"target"`
+}
+
+func (s *Source) UnmarshalJSON(b []byte) error {
+	var unpacker sourceUnpacker
+	if err := json.Unmarshal(b, &unpacker); err != nil {
+		return err
+	}
+
+	s.Type = unpacker.Type
+
+	switch s.Type {
+	case DirectorySourceType, FileSourceType:
+		var path string
+		if err := json.Unmarshal(unpacker.Target, &path); err != nil {
+			return fmt.Errorf("unable to parse %s source target: %w", s.Type, err)
+		}
+		s.Target = path
+	case ImageSourceType:
+		var payload source.ImageMetadata
+		if err := json.Unmarshal(unpacker.Target, &payload); err != nil {
+			return fmt.Errorf("unable to parse image source target: %w", err)
+		}
+		s.Target = payload
+	case "":
+	default:
+		return fmt.Errorf("unsupported source type: %q", s.Type)
+	}
+	return nil
+}
@@ -0,0 +1,192 @@
+package json
+
+import (
+	"sort"
+
+	"github.com/lovewebshell/minicat/internal"
+	"github.com/lovewebshell/minicat/internal/log"
+	"github.com/lovewebshell/minicat/minicat/artifact"
+	"github.com/lovewebshell/minicat/minicat/format/json/model"
+	"github.com/lovewebshell/minicat/minicat/linux"
+	"github.com/lovewebshell/minicat/minicat/pkg"
+	"github.com/lovewebshell/minicat/minicat/sbom"
+	"github.com/lovewebshell/minicat/minicat/source"
+)
+
+func ToFormatModel(s sbom.SBOM) model.Document {
+	return model.Document{
+		Artifacts:             toPackageModels(s.Artifacts.PackageCatalog),
+		ArtifactRelationships: toRelationshipModels(s.Relationships),
+		Files:                 toFileModels(s.Relationships),
+		Source:                toSourceModel(s.Source),
+		Distro:                toLinuxReleaseModel(s.Artifacts.LinuxDistribution),
+		Schema: model.Schema{
+			Version: internal.JSONSchemaVersion,
+		},
+	}
+}
+
+func toPackageModels(catalog *pkg.Catalog) []model.Package {
+	artifacts := make([]model.Package, 0)
+	if catalog == nil {
+		return artifacts
+	}
+	for _, p := range catalog.Sorted() {
+		artifacts = append(artifacts, toPackageModel(p))
+	}
+
+	// the catalog ordering considers file references, which do not survive a round trip, so settle ties here
+	sort.SliceStable(artifacts, func(i, j int) bool {
+		a, b := artifacts[i], artifacts[j]
+		if a.Name != b.Name || a.Version != b.Version || a.Type != b.Type {
+			return false
+		}
+		if firstPath(a) == firstPath(b) {
+			return a.ID < b.ID
+		}
+		return firstPath(a) < firstPath(b)
+	})
+	return artifacts
+}
+
+func firstPath(p model.Package) string {
+	if len(p.Locations) == 0 {
+		return ""
+	}
+	return p.Locations[0].RealPath
+}
+
+func toPackageModel(p pkg.Package) model.Package {
+	var cpes = make([]string, len(p.CPEs))
+	for i, c := range p.CPEs {
+		cpes[i] = pkg.CPEString(c)
+	}
+
+	var licenses = make([]string, 0)
+	if p.Licenses != nil {
+		licenses = p.Licenses
+	}
+
+	var locations = make([]model.Location, 0)
+	for _, l := range p.Locations.ToSlice() {
+		locations = append(locations, model.Location{
+			Coordinates: l.Coordinates,
+			VirtualPath: l.VirtualPath,
+		})
+	}
+
+	return model.Package{
+		ID:           string(p.ID()),
+		Name:         p.Name,
+		Version:      p.Version,
+		Type:         p.Type,
+		FoundBy:      p.FoundBy,
+		Locations:    locations,
+		Licenses:     licenses,
+		Language:     p.Language,
+		CPEs:         cpes,
+		PURL:         p.PURL,
+		GroupName:    p.GroupName,
+		MetadataType: p.MetadataType,
+		Metadata:     p.Metadata,
+	}
+}
+
+func toRelationshipModels(relationships []artifact.Relationship) []model.Relationship {
+	result := make([]model.Relationship, 0)
+	for _, r := range relationships {
+		if r.From == nil || r.To == nil {
+			log.Warnf("dropping relationship with missing endpoint: %+v", r)
+			continue
+		}
+		result = append(result, model.Relationship{
+			Parent:   string(r.From.ID()),
+			Child:    string(r.To.ID()),
+			Type:     string(r.Type),
+			Metadata: r.Data,
+		})
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Parent == result[j].Parent {
+			if result[i].Child == result[j].Child {
+				return result[i].Type < result[j].Type
+			}
+			return result[i].Child < result[j].Child
+		}
+		return result[i].Parent < result[j].Parent
+	})
+
+	return result
+}
+
+func toFileModels(relationships []artifact.Relationship) []model.File {
+	coordinates := source.NewCoordinateSet()
+	for _, r := range relationships {
+		for _, endpoint := range []artifact.Identifiable{r.From, r.To} {
+			if c, ok := endpoint.(source.Coordinates); ok {
+				coordinates.Add(c)
+			}
+		}
+	}
+
+	var files []model.File
+	for _, c := range coordinates.ToSlice() {
+		files = append(files, model.File{
+			ID:       string(c.ID()),
+			Location: c,
+		})
+	}
+	return files
+}
+
+func toSourceModel(src source.Metadata) model.Source {
+	switch src.Scheme {
+	case source.ImageScheme:
+		metadata := src.ImageMetadata
+		if metadata.Tags == nil {
+			metadata.Tags = []string{}
+		}
+		return model.Source{
+			Type:   model.ImageSourceType,
+			Target: metadata,
+		}
+	case source.DirectoryScheme:
+		return model.Source{
+			Type:   model.DirectorySourceType,
+			Target: src.Path,
+		}
+	case source.FileScheme:
+		return model.Source{
+			Type:   model.FileSourceType,
+			Target: src.Path,
+		}
+	default:
+		return model.Source{}
+	}
+}
+
+func toLinuxReleaseModel(d *linux.Release) *model.LinuxRelease {
+	if d == nil {
+		return nil
+	}
+	return &model.LinuxRelease{
+		PrettyName:       d.PrettyName,
+		Name:             d.Name,
+		ID:               d.ID,
+		IDLike:           d.IDLike,
+		Version:          d.Version,
+		VersionID:        d.VersionID,
+		VersionCodename:  d.VersionCodename,
+		BuildID:          d.BuildID,
+		ImageID:          d.ImageID,
+		ImageVersion:     d.ImageVersion,
+		Variant:          d.Variant,
+		VariantID:        d.VariantID,
+		HomeURL:          d.HomeURL,
+		SupportURL:       d.SupportURL,
+		BugReportURL:     d.BugReportURL,
+		PrivacyPolicyURL: d.PrivacyPolicyURL,
+		CPEName:          d.CPEName,
+	}
+}
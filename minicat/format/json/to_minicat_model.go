@@ -0,0 +1,198 @@
+package json
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/lovewebshell/minicat/internal/log"
+	"github.com/lovewebshell/minicat/minicat/artifact"
+	"github.com/lovewebshell/minicat/minicat/format/json/model"
+	"github.com/lovewebshell/minicat/minicat/linux"
+	"github.com/lovewebshell/minicat/minicat/pkg"
+	"github.com/lovewebshell/minicat/minicat/sbom"
+	"github.com/lovewebshell/minicat/minicat/source"
+)
+
+func toMinicatModel(doc model.Document) (*sbom.SBOM, error) {
+	catalog := pkg.NewCatalog()
+	idMap := make(map[string]artifact.Identifiable)
+
+	for _, p := range doc.Artifacts {
+		mp := toMinicatPackage(p)
+		catalog.Add(mp)
+		idMap[p.ID] = mp
+	}
+
+	for _, f := range doc.Files {
+		idMap[f.ID] = f.Location
+	}
+
+	relationships, err := toMinicatRelationships(doc.ArtifactRelationships, idMap)
+	if err != nil {
+		return nil, err
+	}
+
+	src, err := toMinicatSourceMetadata(doc.Source)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sbom.SBOM{
+		Artifacts: sbom.Artifacts{
+			PackageCatalog:    catalog,
+			LinuxDistribution: toMinicatLinuxRelease(doc.Distro),
+		},
+		Relationships: relationships,
+		Source:        src,
+	}, nil
+}
+
+func toMinicatPackage(p model.Package) pkg.Package {
+	var cpes []pkg.CPE
+	for _, c := range p.CPEs {
+		value, err := pkg.NewCPE(c)
+		if err != nil {
+			log.Warnf("excluding invalid CPE %q: %v", c, err)
+			continue
+		}
+		cpes = append(cpes, value)
+	}
+
+	var locations []source.Location
+	for _, l := range p.Locations {
+		locations = append(locations, source.Location{
+			Coordinates: l.Coordinates,
+			VirtualPath: l.VirtualPath,
+		})
+	}
+
+	metadata := p.Metadata
+	if m, ok := metadata.(pkg.JavaMetadata); ok {
+		m.PURL = p.PURL
+		metadata = m
+	}
+
+	out := pkg.Package{
+		Name:         p.Name,
+		Version:      p.Version,
+		FoundBy:      p.FoundBy,
+		Locations:    source.NewLocationSet(locations...),
+		Licenses:     p.Licenses,
+		Language:     p.Language,
+		Type:         p.Type,
+		CPEs:         cpes,
+		PURL:         p.PURL,
+		MetadataType: p.MetadataType,
+		Metadata:     metadata,
+		GroupName:    p.GroupName,
+	}
+
+	out.OverrideID(artifact.ID(p.ID))
+
+	return out
+}
+
+func toMinicatRelationships(relationships []model.Relationship, idMap map[string]artifact.Identifiable) ([]artifact.Relationship, error) {
+	var result []artifact.Relationship
+	for _, r := range relationships {
+		from, ok := idMap[r.Parent]
+		if !ok {
+			log.Warnf("relationship parent not found: %q", r.Parent)
+			continue
+		}
+		to, ok := idMap[r.Child]
+		if !ok {
+			log.Warnf("relationship child not found: %q", r.Child)
+			continue
+		}
+
+		data, err := toMinicatRelationshipData(artifact.RelationshipType(r.Type), r.Metadata)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, artifact.Relationship{
+			From: from,
+			To:   to,
+			Type: artifact.RelationshipType(r.Type),
+			Data: data,
+		})
+	}
+	return result, nil
+}
+
+func toMinicatRelationshipData(ty artifact.RelationshipType, metadata interface{}) (interface{}, error) {
+	raw, ok := metadata.(json.RawMessage)
+	if !ok {
+		return metadata, nil
+	}
+
+	switch ty {
+	case artifact.OwnershipByFileOverlapRelationship:
+		var data pkg.OwnershipByFilesMetadata
+		if err := json.Unmarshal(raw, &data); err != nil {
+			return nil, fmt.Errorf("unable to decode %s relationship metadata: %w", ty, err)
+		}
+		return data, nil
+	default:
+		var data interface{}
+		if err := json.Unmarshal(raw, &data); err != nil {
+			return nil, fmt.Errorf("unable to decode %s relationship metadata: %w", ty, err)
+		}
+		return data, nil
+	}
+}
+
+func toMinicatSourceMetadata(s model.Source) (source.Metadata, error) {
+	switch s.Type {
+	case model.DirectorySourceType:
+		path, _ := s.Target.(string)
+		return source.Metadata{
+			Scheme: source.DirectoryScheme,
+			Path:   path,
+		}, nil
+	case model.FileSourceType:
+		path, _ := s.Target.(string)
+		return source.Metadata{
+			Scheme: source.FileScheme,
+			Path:   path,
+		}, nil
+	case model.ImageSourceType:
+		metadata, ok := s.Target.(source.ImageMetadata)
+		if !ok {
+			return source.Metadata{}, fmt.Errorf("unexpected image source target: %T", s.Target)
+		}
+		return source.Metadata{
+			Scheme:        source.ImageScheme,
+			ImageMetadata: metadata,
+		}, nil
+	case "":
+		return source.Metadata{Scheme: source.UnknownScheme}, nil
+	}
+	return source.Metadata{}, fmt.Errorf("unsupported source type: %q", s.Type)
+}
+
+func toMinicatLinuxRelease(d *model.LinuxRelease) *linux.Release {
+	if d == nil {
+		return nil
+	}
+	return &linux.Release{
+		PrettyName:       d.PrettyName,
+		Name:             d.Name,
+		ID:               d.ID,
+		IDLike:           d.IDLike,
+		Version:          d.Version,
+		VersionID:        d.VersionID,
+		VersionCodename:  d.VersionCodename,
+		BuildID:          d.BuildID,
+		ImageID:          d.ImageID,
+		ImageVersion:     d.ImageVersion,
+		Variant:          d.Variant,
+		VariantID:        d.VariantID,
+		HomeURL:          d.HomeURL,
+		SupportURL:       d.SupportURL,
+		BugReportURL:     d.BugReportURL,
+		PrivacyPolicyURL: d.PrivacyPolicyURL,
+		CPEName:          d.CPEName,
+	}
+}
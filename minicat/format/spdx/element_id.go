@@ -0,0 +1,53 @@
+package spdx
+
+import (
+	"regexp"
+	"strings"
+
+	spdxdoc "github.com/spdx/tools-golang/spdx"
+
+	"github.com/lovewebshell/minicat/minicat/artifact"
+	"github.com/lovewebshell/minicat/minicat/pkg"
+	"github.com/lovewebshell/minicat/minicat/source"
+)
+
+const (
+	documentElementID = spdxdoc.ElementID("DOCUMENT")
+
+	documentRootPrefix = "DocumentRoot-"
+	packagePrefix      = "Package-"
+	filePrefix         = "File-"
+	distroPrefix       = "OperatingSystem-"
+)
+
+var invalidElementIDCharacters = regexp.MustCompile(`[^a-zA-Z0-9.-]+`)
+
+func sanitizeElementID(id string) string {
+	return strings.Trim(invalidElementIDCharacters.ReplaceAllString(id, "-"), "-")
+}
+
+func packageElementID(p pkg.Package) spdxdoc.ElementID {
+	return spdxdoc.ElementID(sanitizeElementID(packagePrefix + string(p.Type) + "-" + p.Name + "-" + string(p.ID())))
+}
+
+func fileElementID(c source.Coordinates) spdxdoc.ElementID {
+	return spdxdoc.ElementID(sanitizeElementID(filePrefix + string(c.ID())))
+}
+
+func identifiableElementID(i artifact.Identifiable) (spdxdoc.ElementID, bool) {
+	switch v := i.(type) {
+	case pkg.Package:
+		return packageElementID(v), true
+	case *pkg.Package:
+		return packageElementID(*v), true
+	case source.Coordinates:
+		return fileElementID(v), true
+	case source.Location:
+		return fileElementID(v.Coordinates), true
+	}
+	return "", false
+}
+
+func docElementID(id spdxdoc.ElementID) spdxdoc.DocElementID {
+	return spdxdoc.DocElementID{ElementRefID: id}
+}
@@ -0,0 +1,52 @@
+/*
+Package spdx provides SPDX (2.3) JSON and tag-value SBOM encoders and decoders. The cataloged source is described as
+the document root, with every package (and the identified distro) contained by it.
+*/
+package spdx
+
+import (
+	"fmt"
+	"io"
+
+	spdxjson "github.com/spdx/tools-golang/json"
+	spdxdoc "github.com/spdx/tools-golang/spdx"
+	spdxtv "github.com/spdx/tools-golang/tagvalue"
+
+	"github.com/lovewebshell/minicat/minicat/sbom"
+)
+
+const (
+	JSONID     = "spdx-json"
+	TagValueID = "spdx-tag-value"
+)
+
+func EncodeJSON(output io.Writer, s sbom.SBOM) error {
+	return spdxjson.Write(ToFormatModel(s), output, spdxjson.Indent(" "), spdxjson.EscapeHTML(false))
+}
+
+func EncodeTagValue(output io.Writer, s sbom.SBOM) error {
+	return spdxtv.Write(ToFormatModel(s), output)
+}
+
+func DecodeJSON(reader io.Reader) (*sbom.SBOM, error) {
+	doc, err := spdxjson.Read(reader)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode spdx-json: %w", err)
+	}
+	return decode(doc)
+}
+
+func DecodeTagValue(reader io.Reader) (*sbom.SBOM, error) {
+	doc, err := spdxtv.Read(reader)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode spdx-tag-value: %w", err)
+	}
+	return decode(doc)
+}
+
+func decode(doc *spdxdoc.Document) (*sbom.SBOM, error) {
+	if doc == nil {
+		return nil, fmt.Errorf("no spdx document found")
+	}
+	return ToMinicatModel(doc)
+}
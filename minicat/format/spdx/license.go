@@ -0,0 +1,246 @@
+package spdx
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	spdxdoc "github.com/spdx/tools-golang/spdx"
+)
+
+const (
+	noAssertion = "NOASSERTION"
+
+	licenseRefPrefix = "LicenseRef-"
+)
+
+var licenseRefExpression = regexp.MustCompile(`^(DocumentRef-[A-Za-z0-9.-]+:)?LicenseRef-[A-Za-z0-9.-]+$`)
+
+type licenseEncoder struct {
+	otherLicenses map[string]*spdxdoc.OtherLicense
+	refs          map[string]string
+}
+
+func newLicenseEncoder() *licenseEncoder {
+	return &licenseEncoder{
+		otherLicenses: make(map[string]*spdxdoc.OtherLicense),
+		refs:          make(map[string]string),
+	}
+}
+
+// declared joins all package licenses into a single SPDX license expression, falling back to LicenseRef-* identifiers
+// (recorded as other licenses) for values that are not valid SPDX expressions.
+func (e *licenseEncoder) declared(licenses []string) string {
+	var parts []string
+	for _, l := range licenses {
+		l = strings.TrimSpace(l)
+		if l == "" {
+			continue
+		}
+		expression, ok := licenseExpression(l)
+		if !ok {
+			parts = append(parts, e.licenseRef(l))
+			continue
+		}
+		if strings.Contains(expression, " ") && !parenthesized(expression) {
+			expression = "(" + expression + ")"
+		}
+		parts = append(parts, expression)
+	}
+
+	if len(parts) == 0 {
+		return noAssertion
+	}
+	return strings.Join(parts, " AND ")
+}
+
+// licenseRef records the license text as an other license, suffixing its identifier when different texts sanitize to
+// the same one.
+func (e *licenseEncoder) licenseRef(license string) string {
+	if id, ok := e.refs[license]; ok {
+		return id
+	}
+
+	base := sanitizeElementID(license)
+	if base == "" {
+		base = "unknown"
+	}
+	base = licenseRefPrefix + base
+	id := base
+	for i := 2; e.otherLicenses[id] != nil; i++ {
+		id = fmt.Sprintf("%s-%d", base, i)
+	}
+
+	e.refs[license] = id
+	e.otherLicenses[id] = &spdxdoc.OtherLicense{
+		LicenseIdentifier: id,
+		LicenseName:       license,
+		ExtractedText:     license,
+	}
+	return id
+}
+
+func (e *licenseEncoder) others() []*spdxdoc.OtherLicense {
+	var result []*spdxdoc.OtherLicense
+	for _, l := range e.otherLicenses {
+		result = append(result, l)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].LicenseIdentifier < result[j].LicenseIdentifier
+	})
+	return result
+}
+
+// decodeLicenses splits a declared license expression on its top level conjunctions, resolving LicenseRef-*
+// identifiers back to their extracted text.
+func decodeLicenses(expression string, otherLicenses map[string]string) []string {
+	expression = strings.TrimSpace(expression)
+	if expression == "" || expression == noAssertion || expression == "NONE" {
+		return nil
+	}
+
+	var result []string
+	for _, part := range splitTopLevelConjunctions(expression) {
+		if parenthesized(part) {
+			part = part[1 : len(part)-1]
+		}
+		if text, ok := otherLicenses[part]; ok {
+			part = text
+		}
+		result = append(result, part)
+	}
+	return result
+}
+
+func splitTopLevelConjunctions(expression string) []string {
+	var parts []string
+	var depth, start int
+	for i := 0; i < len(expression); i++ {
+		switch expression[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ' ':
+			if depth == 0 && strings.HasPrefix(expression[i:], " AND ") {
+				parts = append(parts, strings.TrimSpace(expression[start:i]))
+				start = i + len(" AND ")
+				i = start - 1
+			}
+		}
+	}
+	return append(parts, strings.TrimSpace(expression[start:]))
+}
+
+// parenthesized tells whether the whole expression is grouped by its outer parentheses.
+func parenthesized(expression string) bool {
+	return strings.HasPrefix(expression, "(") && strings.HasSuffix(expression, ")") &&
+		balanced(expression[1:len(expression)-1])
+}
+
+func balanced(s string) bool {
+	var depth int
+	for _, c := range s {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth < 0 {
+				return false
+			}
+		}
+	}
+	return depth == 0
+}
+
+// licenseExpression normalizes the value when it is a valid SPDX license expression: known SPDX license identifiers
+// (possibly followed by "+") and LicenseRef-* identifiers, joined by AND, OR and WITH (followed by a known SPDX
+// exception) and grouped by parentheses.
+func licenseExpression(value string) (string, bool) {
+	p := &licenseExpressionParser{tokens: licenseExpressionTokens(value)}
+	expression, ok := p.or()
+	if !ok || p.pos != len(p.tokens) {
+		return "", false
+	}
+	return expression, true
+}
+
+func licenseExpressionTokens(value string) []string {
+	value = strings.NewReplacer("(", " ( ", ")", " ) ").Replace(value)
+	return strings.Fields(value)
+}
+
+type licenseExpressionParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *licenseExpressionParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *licenseExpressionParser) next() string {
+	token := p.peek()
+	p.pos++
+	return token
+}
+
+func (p *licenseExpressionParser) or() (string, bool) {
+	return p.binary("OR", p.and)
+}
+
+func (p *licenseExpressionParser) and() (string, bool) {
+	return p.binary("AND", p.with)
+}
+
+func (p *licenseExpressionParser) binary(operator string, operand func() (string, bool)) (string, bool) {
+	expression, ok := operand()
+	for ok && p.peek() == operator {
+		p.next()
+		var right string
+		right, ok = operand()
+		expression += " " + operator + " " + right
+	}
+	return expression, ok
+}
+
+func (p *licenseExpressionParser) with() (string, bool) {
+	if p.peek() == "(" {
+		p.next()
+		expression, ok := p.or()
+		if !ok || p.next() != ")" {
+			return "", false
+		}
+		return "(" + expression + ")", true
+	}
+
+	license, ok := p.license()
+	if !ok || p.peek() != "WITH" {
+		return license, ok
+	}
+	p.next()
+	exception, ok := spdxLicenseExceptionIDs[strings.ToLower(p.next())]
+	if !ok {
+		return "", false
+	}
+	return license + " WITH " + exception, true
+}
+
+func (p *licenseExpressionParser) license() (string, bool) {
+	token := p.next()
+	if licenseRefExpression.MatchString(token) {
+		return token, true
+	}
+	if id, ok := spdxLicenseIDs[strings.ToLower(token)]; ok {
+		return id, true
+	}
+	if id, ok := spdxLicenseIDs[strings.ToLower(strings.TrimSuffix(token, "+"))]; ok && strings.HasSuffix(token, "+") {
+		return id + "+", true
+	}
+	return "", false
+}
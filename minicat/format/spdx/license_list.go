@@ -0,0 +1,57 @@
+package spdx
+
+import "strings"
+
+// spdxLicenseIDs are the identifiers of the SPDX license list (https://spdx.org/licenses) that packages commonly
+// declare, keyed by their lower case form since SPDX identifiers match case-insensitively. Licenses missing from it
+// are expressed as LicenseRef-* identifiers.
+var spdxLicenseIDs = lowerCaseIndex(
+	"0BSD", "AAL", "AFL-1.1", "AFL-1.2", "AFL-2.0", "AFL-2.1", "AFL-3.0", "AGPL-1.0", "AGPL-1.0-only",
+	"AGPL-1.0-or-later", "AGPL-3.0", "AGPL-3.0-only", "AGPL-3.0-or-later", "Apache-1.0", "Apache-1.1", "Apache-2.0",
+	"APSL-1.0", "APSL-1.1", "APSL-1.2", "APSL-2.0", "Artistic-1.0", "Artistic-1.0-cl8", "Artistic-1.0-Perl",
+	"Artistic-2.0", "Beerware", "BitTorrent-1.1", "blessing", "BlueOak-1.0.0", "BSD-1-Clause", "BSD-2-Clause",
+	"BSD-2-Clause-FreeBSD", "BSD-2-Clause-NetBSD", "BSD-2-Clause-Patent", "BSD-3-Clause", "BSD-3-Clause-Attribution",
+	"BSD-3-Clause-Clear", "BSD-3-Clause-LBNL", "BSD-3-Clause-No-Nuclear-License", "BSD-4-Clause", "BSD-4-Clause-UC",
+	"BSD-Source-Code", "BSL-1.0", "BUSL-1.1", "bzip2-1.0.6", "CAL-1.0", "CC-BY-1.0", "CC-BY-2.0", "CC-BY-2.5",
+	"CC-BY-3.0", "CC-BY-4.0", "CC-BY-NC-4.0", "CC-BY-NC-ND-4.0", "CC-BY-NC-SA-4.0", "CC-BY-ND-4.0", "CC-BY-SA-1.0",
+	"CC-BY-SA-2.0", "CC-BY-SA-2.5", "CC-BY-SA-3.0", "CC-BY-SA-4.0", "CC0-1.0", "CDDL-1.0", "CDDL-1.1",
+	"CDLA-Permissive-1.0", "CDLA-Permissive-2.0", "CDLA-Sharing-1.0", "CECILL-2.0", "CECILL-2.1", "CECILL-B",
+	"CECILL-C", "ClArtistic", "CPAL-1.0", "CPL-1.0", "curl", "ECL-1.0", "ECL-2.0", "EFL-2.0", "Elastic-2.0",
+	"EPL-1.0", "EPL-2.0", "EUPL-1.0", "EUPL-1.1", "EUPL-1.2", "FSFAP", "FSFUL", "FSFULLR", "FTL", "GFDL-1.1",
+	"GFDL-1.1-only", "GFDL-1.1-or-later", "GFDL-1.2", "GFDL-1.2-only", "GFDL-1.2-or-later", "GFDL-1.3",
+	"GFDL-1.3-only", "GFDL-1.3-or-later", "GPL-1.0", "GPL-1.0+", "GPL-1.0-only", "GPL-1.0-or-later", "GPL-2.0",
+	"GPL-2.0+", "GPL-2.0-only", "GPL-2.0-or-later", "GPL-3.0", "GPL-3.0+", "GPL-3.0-only", "GPL-3.0-or-later",
+	"HPND", "ICU", "IJG", "ImageMagick", "Info-ZIP", "IPA", "IPL-1.0", "ISC", "JSON", "LGPL-2.0", "LGPL-2.0+",
+	"LGPL-2.0-only", "LGPL-2.0-or-later", "LGPL-2.1", "LGPL-2.1+", "LGPL-2.1-only", "LGPL-2.1-or-later", "LGPL-3.0",
+	"LGPL-3.0+", "LGPL-3.0-only", "LGPL-3.0-or-later", "LGPLLR", "Libpng", "libpng-2.0", "libtiff", "LPL-1.02",
+	"LPPL-1.3c", "MirOS", "MIT", "MIT-0", "MIT-advertising", "MIT-CMU", "MIT-enna", "MIT-feh", "MIT-Modern-Variant",
+	"MITNFA", "MPL-1.0", "MPL-1.1", "MPL-2.0", "MPL-2.0-no-copyleft-exception", "MS-PL", "MS-RL", "MulanPSL-2.0",
+	"NCSA", "Net-SNMP", "NPL-1.1", "NTP", "ODbL-1.0", "ODC-By-1.0", "OFL-1.0", "OFL-1.1", "OLDAP-2.8", "OpenSSL",
+	"OSL-1.0", "OSL-2.0", "OSL-2.1", "OSL-3.0", "PDDL-1.0", "PHP-3.0", "PHP-3.01", "PostgreSQL", "PSF-2.0",
+	"Python-2.0", "Python-2.0.1", "Qhull", "QPL-1.0", "Ruby", "SGI-B-2.0", "SISSL", "Sleepycat", "SMLNJ", "SSPL-1.0",
+	"TCL", "Unicode-3.0", "Unicode-DFS-2015", "Unicode-DFS-2016", "Unlicense", "UPL-1.0", "Vim", "W3C",
+	"W3C-20150513", "WTFPL", "X11", "XFree86-1.1", "Xnet", "Zend-2.0", "Zlib", "zlib-acknowledgement", "ZPL-2.0",
+	"ZPL-2.1",
+)
+
+// spdxLicenseExceptionIDs are the identifiers of the SPDX license exceptions
+// (https://spdx.org/licenses/exceptions-index.html) that may follow a WITH operator, keyed by their lower case form.
+var spdxLicenseExceptionIDs = lowerCaseIndex(
+	"389-exception", "Autoconf-exception-2.0", "Autoconf-exception-3.0", "Bison-exception-2.2",
+	"Bootloader-exception", "Classpath-exception-2.0", "CLISP-exception-2.0", "eCos-exception-2.0",
+	"FLTK-exception", "Font-exception-2.0", "freertos-exception-2.0", "GCC-exception-2.0", "GCC-exception-3.1",
+	"gnu-javamail-exception", "GPL-3.0-linking-exception", "GPL-3.0-linking-source-exception", "GPL-CC-1.0",
+	"i2p-gpl-java-exception", "LGPL-3.0-linking-exception", "Libtool-exception", "Linux-syscall-note",
+	"LLVM-exception", "LZMA-exception", "mif-exception", "Nokia-Qt-exception-1.1", "OCaml-LGPL-linking-exception",
+	"OpenJDK-assembly-exception-1.0", "openvpn-openssl-exception", "PS-or-PDF-font-exception-20170817",
+	"Qt-GPL-exception-1.0", "Qt-LGPL-exception-1.1", "Swift-exception", "u-boot-exception-2.0",
+	"Universal-FOSS-exception-1.0", "WxWindows-exception-3.1",
+)
+
+func lowerCaseIndex(ids ...string) map[string]string {
+	index := make(map[string]string, len(ids))
+	for _, id := range ids {
+		index[strings.ToLower(id)] = id
+	}
+	return index
+}
@@ -0,0 +1,60 @@
+package spdx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLicenseEncoder_Declared(t *testing.T) {
+	tests := []struct {
+		name     string
+		licenses []string
+		expected string
+		others   []string
+	}{
+		{
+			name:     "no licenses",
+			expected: noAssertion,
+		},
+		{
+			name:     "spdx identifiers",
+			licenses: []string{"mit", "Apache-2.0"},
+			expected: "MIT AND Apache-2.0",
+		},
+		{
+			name:     "spdx expressions",
+			licenses: []string{"GPL-2.0-or-later WITH Classpath-exception-2.0", "(MIT OR Apache-2.0)", "(MIT) OR (BSD-3-Clause)"},
+			expected: "(GPL-2.0-or-later WITH Classpath-exception-2.0) AND (MIT OR Apache-2.0) AND ((MIT) OR (BSD-3-Clause))",
+		},
+		{
+			name:     "license refs and or-later suffixes",
+			licenses: []string{"LicenseRef-custom OR LGPL-2.1+"},
+			expected: "(LicenseRef-custom OR LGPL-2.1+)",
+		},
+		{
+			name:     "free text",
+			licenses: []string{"MIT License", "Apache Software License", "MIT AND", "MIT WITH GPL-2.0"},
+			expected: "LicenseRef-MIT-License AND LicenseRef-Apache-Software-License AND LicenseRef-MIT-AND AND LicenseRef-MIT-WITH-GPL-2.0",
+			others:   []string{"LicenseRef-Apache-Software-License", "LicenseRef-MIT-AND", "LicenseRef-MIT-License", "LicenseRef-MIT-WITH-GPL-2.0"},
+		},
+		{
+			name:     "texts sanitized to the same identifier",
+			licenses: []string{"Public Domain", "Public-Domain", "Public Domain"},
+			expected: "LicenseRef-Public-Domain AND LicenseRef-Public-Domain-2 AND LicenseRef-Public-Domain",
+			others:   []string{"LicenseRef-Public-Domain", "LicenseRef-Public-Domain-2"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			e := newLicenseEncoder()
+			assert.Equal(t, test.expected, e.declared(test.licenses))
+
+			var others []string
+			for _, l := range e.others() {
+				others = append(others, l.LicenseIdentifier)
+			}
+			assert.Equal(t, test.others, others)
+		})
+	}
+}
@@ -0,0 +1,387 @@
+package spdx
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	spdxdoc "github.com/spdx/tools-golang/spdx"
+
+	"github.com/lovewebshell/minicat/internal"
+	"github.com/lovewebshell/minicat/internal/log"
+	"github.com/lovewebshell/minicat/minicat/artifact"
+	"github.com/lovewebshell/minicat/minicat/file"
+	"github.com/lovewebshell/minicat/minicat/linux"
+	"github.com/lovewebshell/minicat/minicat/pkg"
+	"github.com/lovewebshell/minicat/minicat/sbom"
+	"github.com/lovewebshell/minicat/minicat/source"
+)
+
+const (
+	namespaceBase = "https://github.com/lovewebshell/minicat"
+
+	purposeContainer       = "CONTAINER"
+	purposeFile            = "FILE"
+	purposeLibrary         = "LIBRARY"
+	purposeOperatingSystem = "OPERATING-SYSTEM"
+
+	evidentByCommentPrefix   = "evident-by: "
+	ownershipCommentPrefix   = string(artifact.OwnershipByFileOverlapRelationship) + ": "
+	layerIDFileCommentPrefix = "layerID: "
+)
+
+func ToFormatModel(s sbom.SBOM) *spdxdoc.Document {
+	name := documentName(s.Source)
+	licenses := newLicenseEncoder()
+
+	doc := &spdxdoc.Document{
+		SPDXVersion:       spdxdoc.Version,
+		DataLicense:       spdxdoc.DataLicense,
+		SPDXIdentifier:    documentElementID,
+		DocumentName:      name,
+		DocumentNamespace: documentNamespace(name, s.Source),
+		CreationInfo: &spdxdoc.CreationInfo{
+			Creators: []spdxdoc.Creator{
+				{
+					CreatorType: "Tool",
+					Creator:     internal.ApplicationName,
+				},
+			},
+			Created: time.Now().UTC().Format(time.RFC3339),
+		},
+	}
+
+	var packages []pkg.Package
+	if s.Artifacts.PackageCatalog != nil {
+		packages = s.Artifacts.PackageCatalog.Sorted()
+	}
+
+	var contained []spdxdoc.ElementID
+	files := make(map[spdxdoc.ElementID]*spdxdoc.File)
+	for _, p := range packages {
+		doc.Packages = append(doc.Packages, toPackage(p, licenses))
+		contained = append(contained, packageElementID(p))
+
+		for _, l := range p.Locations.ToSlice() {
+			id := toFile(l.Coordinates, files)
+			doc.Relationships = append(doc.Relationships, &spdxdoc.Relationship{
+				RefA:                docElementID(packageElementID(p)),
+				RefB:                docElementID(id),
+				Relationship:        spdxdoc.RelationshipOther,
+				RelationshipComment: evidentByCommentPrefix + l.RealPath,
+			})
+		}
+	}
+
+	if distro := toDistroPackage(s.Artifacts.LinuxDistribution); distro != nil {
+		doc.Packages = append(doc.Packages, distro)
+		contained = append(contained, distro.PackageSPDXIdentifier)
+	}
+
+	if root := toRootPackage(s.Source); root != nil {
+		doc.Packages = append(doc.Packages, root)
+		doc.Relationships = append(doc.Relationships, &spdxdoc.Relationship{
+			RefA:         docElementID(documentElementID),
+			RefB:         docElementID(root.PackageSPDXIdentifier),
+			Relationship: spdxdoc.RelationshipDescribes,
+		})
+		for _, id := range contained {
+			doc.Relationships = append(doc.Relationships, &spdxdoc.Relationship{
+				RefA:         docElementID(root.PackageSPDXIdentifier),
+				RefB:         docElementID(id),
+				Relationship: spdxdoc.RelationshipContains,
+			})
+		}
+	}
+
+	doc.Relationships = append(doc.Relationships, toRelationships(s.Relationships, files)...)
+
+	for _, f := range files {
+		doc.Files = append(doc.Files, f)
+	}
+	sort.Slice(doc.Files, func(i, j int) bool {
+		return doc.Files[i].FileSPDXIdentifier < doc.Files[j].FileSPDXIdentifier
+	})
+
+	doc.OtherLicenses = licenses.others()
+
+	return doc
+}
+
+func documentName(src source.Metadata) string {
+	switch src.Scheme {
+	case source.ImageScheme:
+		return src.ImageMetadata.UserInput
+	case source.DirectoryScheme, source.FileScheme:
+		return src.Path
+	}
+	return internal.ApplicationName
+}
+
+func documentNamespace(name string, src source.Metadata) string {
+	var input string
+	switch src.Scheme {
+	case source.ImageScheme:
+		input = "image"
+	case source.DirectoryScheme:
+		input = "dir"
+	case source.FileScheme:
+		input = "file"
+	default:
+		input = "unknown-source-type"
+	}
+
+	uniqueID := uuid.New()
+	identifier := path.Join(input, sanitizeElementID(name)+"-"+uniqueID.String())
+
+	return namespaceBase + "/" + identifier
+}
+
+func toRootPackage(src source.Metadata) *spdxdoc.Package {
+	var p *spdxdoc.Package
+	switch src.Scheme {
+	case source.ImageScheme:
+		p = &spdxdoc.Package{
+			PackageName:           src.ImageMetadata.UserInput,
+			PackageSPDXIdentifier: spdxdoc.ElementID(sanitizeElementID(documentRootPrefix + "Image-" + src.ImageMetadata.UserInput)),
+			PackageVersion:        src.ImageMetadata.ManifestDigest,
+			PrimaryPackagePurpose: purposeContainer,
+		}
+	case source.DirectoryScheme:
+		p = &spdxdoc.Package{
+			PackageName:           src.Path,
+			PackageSPDXIdentifier: spdxdoc.ElementID(sanitizeElementID(documentRootPrefix + "Directory-" + src.Path)),
+			PrimaryPackagePurpose: purposeFile,
+		}
+	case source.FileScheme:
+		p = &spdxdoc.Package{
+			PackageName:           src.Path,
+			PackageSPDXIdentifier: spdxdoc.ElementID(sanitizeElementID(documentRootPrefix + "File-" + src.Path)),
+			PrimaryPackagePurpose: purposeFile,
+		}
+	default:
+		return nil
+	}
+
+	p.PackageDownloadLocation = noAssertion
+	p.PackageLicenseConcluded = noAssertion
+	p.PackageLicenseDeclared = noAssertion
+	p.PackageCopyrightText = noAssertion
+	p.IsFilesAnalyzedTagPresent = true
+	return p
+}
+
+func toDistroPackage(release *linux.Release) *spdxdoc.Package {
+	if release == nil {
+		return nil
+	}
+
+	p := &spdxdoc.Package{
+		PackageName:               release.ID,
+		PackageSPDXIdentifier:     spdxdoc.ElementID(sanitizeElementID(distroPrefix + release.ID + "-" + release.VersionID)),
+		PackageVersion:            release.VersionID,
+		PackageDescription:        release.PrettyName,
+		PackageHomePage:           release.HomeURL,
+		PackageDownloadLocation:   noAssertion,
+		PackageLicenseConcluded:   noAssertion,
+		PackageLicenseDeclared:    noAssertion,
+		PackageCopyrightText:      noAssertion,
+		IsFilesAnalyzedTagPresent: true,
+		PrimaryPackagePurpose:     purposeOperatingSystem,
+	}
+
+	if release.CPEName != "" {
+		p.PackageExternalReferences = append(p.PackageExternalReferences, &spdxdoc.PackageExternalReference{
+			Category: spdxdoc.CategorySecurity,
+			RefType:  spdxdoc.SecurityCPE23Type,
+			Locator:  release.CPEName,
+		})
+	}
+
+	return p
+}
+
+func toPackage(p pkg.Package, licenses *licenseEncoder) *spdxdoc.Package {
+	return &spdxdoc.Package{
+		PackageName:               p.Name,
+		PackageSPDXIdentifier:     packageElementID(p),
+		PackageVersion:            p.Version,
+		PackageDownloadLocation:   noAssertion,
+		IsFilesAnalyzedTagPresent: true,
+		PackageChecksums:          toChecksums(p),
+		PackageLicenseConcluded:   noAssertion,
+		PackageLicenseDeclared:    licenses.declared(p.Licenses),
+		PackageCopyrightText:      noAssertion,
+		PackageSourceInfo:         sourceInfo(p),
+		PackageExternalReferences: toExternalReferences(p),
+		PrimaryPackagePurpose:     purposeLibrary,
+	}
+}
+
+func sourceInfo(p pkg.Package) string {
+	var paths []string
+	for _, l := range p.Locations.ToSlice() {
+		paths = append(paths, l.RealPath)
+	}
+	if len(paths) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("acquired package info from %s: %s", p.FoundBy, strings.Join(paths, ", "))
+}
+
+func toExternalReferences(p pkg.Package) []*spdxdoc.PackageExternalReference {
+	var references []*spdxdoc.PackageExternalReference
+	for _, c := range p.CPEs {
+		references = append(references, &spdxdoc.PackageExternalReference{
+			Category: spdxdoc.CategorySecurity,
+			RefType:  spdxdoc.SecurityCPE23Type,
+			Locator:  pkg.CPEString(c),
+		})
+	}
+	if p.PURL != "" {
+		references = append(references, &spdxdoc.PackageExternalReference{
+			Category: spdxdoc.CategoryPackageManager,
+			RefType:  spdxdoc.PackageManagerPURL,
+			Locator:  p.PURL,
+		})
+	}
+	return references
+}
+
+func toChecksums(p pkg.Package) []spdxdoc.Checksum {
+	var digests []file.Digest
+	switch m := p.Metadata.(type) {
+	case pkg.JavaMetadata:
+		digests = m.ArchiveDigests
+	}
+
+	var checksums []spdxdoc.Checksum
+	for _, d := range digests {
+		algorithm := toChecksumAlgorithm(d.Algorithm)
+		if algorithm == "" {
+			log.Debugf("unable to express %q digest of package=%q in spdx", d.Algorithm, p.Name)
+			continue
+		}
+		checksums = append(checksums, spdxdoc.Checksum{
+			Algorithm: algorithm,
+			Value:     d.Value,
+		})
+	}
+	return checksums
+}
+
+func toChecksumAlgorithm(algorithm string) spdxdoc.ChecksumAlgorithm {
+	switch strings.ToLower(strings.ReplaceAll(algorithm, "-", "")) {
+	case "md5":
+		return spdxdoc.MD5
+	case "sha1":
+		return spdxdoc.SHA1
+	case "sha224":
+		return spdxdoc.SHA224
+	case "sha256":
+		return spdxdoc.SHA256
+	case "sha384":
+		return spdxdoc.SHA384
+	case "sha512":
+		return spdxdoc.SHA512
+	}
+	return ""
+}
+
+func toFile(c source.Coordinates, files map[spdxdoc.ElementID]*spdxdoc.File) spdxdoc.ElementID {
+	id := fileElementID(c)
+	if _, exists := files[id]; exists {
+		return id
+	}
+
+	f := &spdxdoc.File{
+		FileName:           c.RealPath,
+		FileSPDXIdentifier: id,
+		Checksums:          []spdxdoc.Checksum{},
+		LicenseConcluded:   noAssertion,
+		FileCopyrightText:  noAssertion,
+	}
+	if c.FileSystemID != "" {
+		f.FileComment = layerIDFileCommentPrefix + c.FileSystemID
+	}
+	files[id] = f
+	return id
+}
+
+func toRelationships(relationships []artifact.Relationship, files map[spdxdoc.ElementID]*spdxdoc.File) []*spdxdoc.Relationship {
+	var result []*spdxdoc.Relationship
+	for _, r := range relationships {
+		ty, comment, ok := toRelationshipType(r)
+		if !ok {
+			log.Debugf("unable to express %s relationship in spdx, dropping: %+v", r.Type, r)
+			continue
+		}
+
+		from, ok := identifiableElementID(r.From)
+		if !ok {
+			log.Debugf("unable to express %s relationship source in spdx, dropping: %+v", r.Type, r)
+			continue
+		}
+
+		var to spdxdoc.ElementID
+		switch v := r.To.(type) {
+		case source.Coordinates:
+			to = toFile(v, files)
+		case source.Location:
+			to = toFile(v.Coordinates, files)
+		default:
+			if to, ok = identifiableElementID(r.To); !ok {
+				log.Debugf("unable to express %s relationship target in spdx, dropping: %+v", r.Type, r)
+				continue
+			}
+		}
+
+		result = append(result, &spdxdoc.Relationship{
+			RefA:                docElementID(from),
+			RefB:                docElementID(to),
+			Relationship:        ty,
+			RelationshipComment: comment,
+		})
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.RefA.ElementRefID != b.RefA.ElementRefID {
+			return a.RefA.ElementRefID < b.RefA.ElementRefID
+		}
+		if a.RefB.ElementRefID != b.RefB.ElementRefID {
+			return a.RefB.ElementRefID < b.RefB.ElementRefID
+		}
+		return a.Relationship < b.Relationship
+	})
+
+	return result
+}
+
+func toRelationshipType(r artifact.Relationship) (string, string, bool) {
+	switch r.Type {
+	case artifact.ContainsRelationship:
+		return spdxdoc.RelationshipContains, "", true
+	case artifact.DependencyOfRelationship:
+		return spdxdoc.RelationshipDependencyOf, "", true
+	case artifact.RuntimeDependencyOfRelationship:
+		return spdxdoc.RelationshipRuntimeDependencyOf, "", true
+	case artifact.DevDependencyOfRelationship:
+		return spdxdoc.RelationshipDevDependencyOf, "", true
+	case artifact.BuildDependencyOfRelationship:
+		return spdxdoc.RelationshipBuildDependencyOf, "", true
+	case artifact.OwnershipByFileOverlapRelationship:
+		var files []string
+		switch d := r.Data.(type) {
+		case pkg.OwnershipByFilesMetadata:
+			files = d.Files
+		case *pkg.OwnershipByFilesMetadata:
+			files = d.Files
+		}
+		return spdxdoc.RelationshipOther, ownershipCommentPrefix + strings.Join(files, ", "), true
+	}
+	return "", "", false
+}
@@ -0,0 +1,265 @@
+package spdx
+
+import (
+	"strings"
+
+	spdxdoc "github.com/spdx/tools-golang/spdx"
+
+	"github.com/lovewebshell/minicat/internal/log"
+	"github.com/lovewebshell/minicat/minicat/artifact"
+	"github.com/lovewebshell/minicat/minicat/file"
+	"github.com/lovewebshell/minicat/minicat/linux"
+	"github.com/lovewebshell/minicat/minicat/pkg"
+	"github.com/lovewebshell/minicat/minicat/sbom"
+	"github.com/lovewebshell/minicat/minicat/source"
+)
+
+const sourceInfoPrefix = "acquired package info from "
+
+func ToMinicatModel(doc *spdxdoc.Document) (*sbom.SBOM, error) {
+	s := &sbom.SBOM{
+		Artifacts: sbom.Artifacts{
+			PackageCatalog: pkg.NewCatalog(),
+		},
+		Source: source.Metadata{Scheme: source.UnknownScheme},
+	}
+
+	otherLicenses := make(map[string]string)
+	for _, l := range doc.OtherLicenses {
+		if l != nil {
+			otherLicenses[l.LicenseIdentifier] = l.ExtractedText
+		}
+	}
+
+	files := make(map[spdxdoc.ElementID]source.Coordinates)
+	for _, f := range doc.Files {
+		if f != nil {
+			files[f.FileSPDXIdentifier] = toCoordinates(f)
+		}
+	}
+	for _, p := range doc.Packages {
+		if p == nil {
+			continue
+		}
+		for _, f := range p.Files {
+			if f != nil {
+				files[f.FileSPDXIdentifier] = toCoordinates(f)
+			}
+		}
+	}
+
+	described := make(map[spdxdoc.ElementID]bool)
+	evidence := make(map[spdxdoc.ElementID][]source.Location)
+	for _, r := range doc.Relationships {
+		if r == nil {
+			continue
+		}
+		switch {
+		case r.Relationship == spdxdoc.RelationshipDescribes && r.RefA.ElementRefID == documentElementID:
+			described[r.RefB.ElementRefID] = true
+		case r.Relationship == spdxdoc.RelationshipOther && strings.HasPrefix(r.RelationshipComment, evidentByCommentPrefix):
+			if c, ok := files[r.RefB.ElementRefID]; ok {
+				evidence[r.RefA.ElementRefID] = append(evidence[r.RefA.ElementRefID], source.NewLocationFromCoordinates(c))
+			}
+		}
+	}
+
+	packagesByID := make(map[spdxdoc.ElementID]*pkg.Package)
+	for _, p := range doc.Packages {
+		if p == nil {
+			continue
+		}
+		switch {
+		case described[p.PackageSPDXIdentifier]:
+			s.Source = toMinicatSourceMetadata(p)
+		case p.PrimaryPackagePurpose == purposeOperatingSystem:
+			if s.Artifacts.LinuxDistribution == nil {
+				s.Artifacts.LinuxDistribution = toLinuxRelease(p)
+			}
+		default:
+			minicatPkg := toMinicatPackage(p, evidence[p.PackageSPDXIdentifier], otherLicenses)
+			if !pkg.IsValid(minicatPkg) {
+				log.Debugf("skipping invalid spdx package: %q", p.PackageSPDXIdentifier)
+				continue
+			}
+			s.Artifacts.PackageCatalog.Add(*minicatPkg)
+			packagesByID[p.PackageSPDXIdentifier] = minicatPkg
+		}
+	}
+
+	s.Relationships = toMinicatRelationships(doc.Relationships, packagesByID, files)
+
+	return s, nil
+}
+
+func toCoordinates(f *spdxdoc.File) source.Coordinates {
+	c := source.Coordinates{
+		RealPath: f.FileName,
+	}
+	if strings.HasPrefix(f.FileComment, layerIDFileCommentPrefix) {
+		c.FileSystemID = strings.TrimPrefix(f.FileComment, layerIDFileCommentPrefix)
+	}
+	return c
+}
+
+func toMinicatSourceMetadata(p *spdxdoc.Package) source.Metadata {
+	id := string(p.PackageSPDXIdentifier)
+	switch {
+	case strings.HasPrefix(id, documentRootPrefix+"Image-"), p.PrimaryPackagePurpose == purposeContainer:
+		return source.Metadata{
+			Scheme: source.ImageScheme,
+			ImageMetadata: source.ImageMetadata{
+				UserInput:      p.PackageName,
+				ManifestDigest: p.PackageVersion,
+			},
+		}
+	case strings.HasPrefix(id, documentRootPrefix+"File-"):
+		return source.Metadata{
+			Scheme: source.FileScheme,
+			Path:   p.PackageName,
+		}
+	case strings.HasPrefix(id, documentRootPrefix+"Directory-"), p.PrimaryPackagePurpose == purposeFile:
+		return source.Metadata{
+			Scheme: source.DirectoryScheme,
+			Path:   p.PackageName,
+		}
+	}
+	return source.Metadata{Scheme: source.UnknownScheme}
+}
+
+func toLinuxRelease(p *spdxdoc.Package) *linux.Release {
+	release := &linux.Release{
+		ID:         p.PackageName,
+		VersionID:  p.PackageVersion,
+		PrettyName: p.PackageDescription,
+		HomeURL:    p.PackageHomePage,
+	}
+	for _, ref := range p.PackageExternalReferences {
+		if ref != nil && ref.RefType == spdxdoc.SecurityCPE23Type {
+			release.CPEName = ref.Locator
+		}
+	}
+	return release
+}
+
+func toMinicatPackage(p *spdxdoc.Package, locations []source.Location, otherLicenses map[string]string) *pkg.Package {
+	minicatPkg := &pkg.Package{
+		Name:      p.PackageName,
+		Version:   p.PackageVersion,
+		FoundBy:   foundBy(p.PackageSourceInfo),
+		Licenses:  decodeLicenses(p.PackageLicenseDeclared, otherLicenses),
+		Locations: source.NewLocationSet(locations...),
+	}
+
+	for _, ref := range p.PackageExternalReferences {
+		if ref == nil {
+			continue
+		}
+		switch ref.RefType {
+		case spdxdoc.SecurityCPE23Type:
+			c, err := pkg.NewCPE(ref.Locator)
+			if err != nil {
+				log.Warnf("excluding invalid CPE %q: %v", ref.Locator, err)
+				continue
+			}
+			minicatPkg.CPEs = append(minicatPkg.CPEs, c)
+		case spdxdoc.PackageManagerPURL:
+			minicatPkg.PURL = ref.Locator
+		}
+	}
+
+	minicatPkg.Type = pkg.TypeFromPURL(minicatPkg.PURL)
+	minicatPkg.Language = pkg.LanguageFromPURL(minicatPkg.PURL)
+
+	if minicatPkg.Type == pkg.JavaPkg {
+		minicatPkg.MetadataType = pkg.JavaMetadataType
+		minicatPkg.Metadata = pkg.JavaMetadata{
+			PURL:           minicatPkg.PURL,
+			ArchiveDigests: toDigests(p.PackageChecksums),
+		}
+	}
+
+	minicatPkg.SetID()
+
+	return minicatPkg
+}
+
+func foundBy(sourceInfo string) string {
+	if !strings.HasPrefix(sourceInfo, sourceInfoPrefix) {
+		return ""
+	}
+	fields := strings.SplitN(strings.TrimPrefix(sourceInfo, sourceInfoPrefix), ":", 2)
+	return strings.TrimSpace(fields[0])
+}
+
+func toDigests(checksums []spdxdoc.Checksum) (digests []file.Digest) {
+	for _, c := range checksums {
+		digests = append(digests, file.Digest{
+			Algorithm: strings.ToLower(strings.ReplaceAll(string(c.Algorithm), "-", "")),
+			Value:     c.Value,
+		})
+	}
+	return digests
+}
+
+func toMinicatRelationships(relationships []*spdxdoc.Relationship, packagesByID map[spdxdoc.ElementID]*pkg.Package, files map[spdxdoc.ElementID]source.Coordinates) []artifact.Relationship {
+	var result []artifact.Relationship
+	for _, r := range relationships {
+		if r == nil {
+			continue
+		}
+
+		from, ok := packagesByID[r.RefA.ElementRefID]
+		if !ok {
+			continue
+		}
+
+		var ty artifact.RelationshipType
+		var data interface{}
+		switch r.Relationship {
+		case spdxdoc.RelationshipContains:
+			ty = artifact.ContainsRelationship
+		case spdxdoc.RelationshipDependencyOf:
+			ty = artifact.DependencyOfRelationship
+		case spdxdoc.RelationshipRuntimeDependencyOf:
+			ty = artifact.RuntimeDependencyOfRelationship
+		case spdxdoc.RelationshipDevDependencyOf:
+			ty = artifact.DevDependencyOfRelationship
+		case spdxdoc.RelationshipBuildDependencyOf:
+			ty = artifact.BuildDependencyOfRelationship
+		case spdxdoc.RelationshipOther:
+			if !strings.HasPrefix(r.RelationshipComment, ownershipCommentPrefix) {
+				continue
+			}
+			ty = artifact.OwnershipByFileOverlapRelationship
+			var owned []string
+			for _, f := range strings.Split(strings.TrimPrefix(r.RelationshipComment, ownershipCommentPrefix), ", ") {
+				if f != "" {
+					owned = append(owned, f)
+				}
+			}
+			data = pkg.OwnershipByFilesMetadata{Files: owned}
+		default:
+			log.Debugf("unsupported spdx relationship type, dropping: %q", r.Relationship)
+			continue
+		}
+
+		var to artifact.Identifiable
+		if p, ok := packagesByID[r.RefB.ElementRefID]; ok {
+			to = *p
+		} else if c, ok := files[r.RefB.ElementRefID]; ok && ty == artifact.ContainsRelationship {
+			to = c
+		} else {
+			log.Debugf("spdx relationship ref not found: %q", r.RefB.ElementRefID)
+			continue
+		}
+
+		result = append(result, artifact.Relationship{
+			From: *from,
+			To:   to,
+			Type: ty,
+			Data: data,
+		})
+	}
+	return result
+}
@@ -1,7 +1,12 @@
 package minicat
 
 import (
+	"context"
 	"fmt"
+
+	"github.com/wagoodman/go-partybus"
+
+	"github.com/lovewebshell/minicat/internal/bus"
 	"github.com/lovewebshell/minicat/internal/log"
 	"github.com/lovewebshell/minicat/minicat/artifact"
 	"github.com/lovewebshell/minicat/minicat/linux"
@@ -11,7 +16,7 @@ import (
 	"github.com/lovewebshell/minicat/minicat/source"
 )
 
-func CatalogPackages(src *source.Source, cfg cataloger.Config) (*pkg.Catalog, []artifact.Relationship, *linux.Release, error) {
+func CatalogPackages(ctx context.Context, src *source.Source, cfg cataloger.Config) (*pkg.Catalog, []artifact.Relationship, *linux.Release, error) {
 	resolver, err := src.FileResolver(cfg.Search.Scope)
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("unable to determine resolver while cataloging packages: %w", err)
@@ -44,7 +49,7 @@ func CatalogPackages(src *source.Source, cfg cataloger.Config) (*pkg.Catalog, []
 		}
 	}
 
-	catalog, relationships, err := cataloger.Catalog(resolver, release, catalogers...)
+	catalog, relationships, err := cataloger.Catalog(ctx, resolver, release, cfg, catalogers...)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -55,3 +60,12 @@ func CatalogPackages(src *source.Source, cfg cataloger.Config) (*pkg.Catalog, []
 func SetLogger(logger logger.Logger) {
 	log.Log = logger
 }
+
+// SetBus sets the event bus that cataloging progress is published onto; see the event package for the event types.
+func SetBus(b *partybus.Bus) {
+	if b == nil {
+		bus.SetPublisher(nil)
+		return
+	}
+	bus.SetPublisher(b)
+}
@@ -0,0 +1,9 @@
+package pkg
+
+type CargoPackageMetadata struct {
+	Name         string   `toml:"name" json:"name" cyclonedx:"name"`
+	Version      string   `toml:"version" json:"version" cyclonedx:"version"`
+	Source       string   `toml:"source" json:"source" cyclonedx:"source"`
+	Checksum     string   `toml:"checksum" json:"checksum" cyclonedx:"checksum"`
+	Dependencies []string `toml:"dependencies" json:"dependencies" cyclonedx:"dependencies"`
+}
@@ -1,6 +1,7 @@
 package alpm
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/lovewebshell/minicat/internal"
@@ -21,14 +22,17 @@ func (c *Cataloger) Name() string {
 	return catalogerName
 }
 
-func (c *Cataloger) Catalog(resolver source.FileResolver) ([]pkg.Package, []artifact.Relationship, error) {
-	fileMatches, err := resolver.FilesByGlob(pkg.AlpmDBGlob)
+func (c *Cataloger) Catalog(ctx context.Context, resolver source.FileResolver) ([]pkg.Package, []artifact.Relationship, error) {
+	fileMatches, err := resolver.FilesByGlob(ctx, pkg.AlpmDBGlob)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to find rpmdb's by glob: %w", err)
 	}
 
 	var pkgs []pkg.Package
 	for _, location := range fileMatches {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
 		dbContentReader, err := resolver.FileContentsByLocation(location)
 		if err != nil {
 			return nil, nil, err
@@ -2,6 +2,7 @@ package apkdb
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"io"
 	"path"
@@ -30,7 +31,7 @@ func newApkDBPackage(d *pkg.ApkMetadata) *pkg.Package {
 	}
 }
 
-func parseApkDB(_ string, reader io.Reader) ([]*pkg.Package, []artifact.Relationship, error) {
+func parseApkDB(_ context.Context, _ string, reader io.Reader) ([]*pkg.Package, []artifact.Relationship, error) {
 
 	const maxScannerCapacity = 1024 * 1024
 
@@ -1,13 +1,24 @@
 package cataloger
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
 
 	"github.com/hashicorp/go-multierror"
+	"github.com/scylladb/go-set/strset"
+	"github.com/wagoodman/go-partybus"
 	"github.com/wagoodman/go-progress"
 
+	"github.com/lovewebshell/minicat/internal/bus"
 	"github.com/lovewebshell/minicat/internal/log"
+	"github.com/lovewebshell/minicat/internal/monitor"
 	"github.com/lovewebshell/minicat/minicat/artifact"
+	"github.com/lovewebshell/minicat/minicat/event"
 	"github.com/lovewebshell/minicat/minicat/linux"
 	"github.com/lovewebshell/minicat/minicat/pkg"
 	"github.com/lovewebshell/minicat/minicat/pkg/cataloger/common/cpe"
@@ -19,69 +30,222 @@ type Monitor struct {
 	PackagesDiscovered progress.Monitorable
 }
 
-func newMonitor() (*progress.Manual, *progress.Manual) {
-	filesProcessed := progress.Manual{}
-	packagesDiscovered := progress.Manual{}
+func newMonitor() (*monitor.Counter, *monitor.Counter) {
+	return monitor.NewCounter(0), monitor.NewCounter(0)
+}
+
+// CancelledError is returned when a cataloger is stopped before finishing, either because the context given to Catalog
+// was cancelled or because the cataloger exceeded its configured timeout.
+type CancelledError struct {
+	Cataloger string
+	Err       error
+}
+
+func (e *CancelledError) Error() string {
+	return fmt.Sprintf("cataloger %q cancelled: %v", e.Cataloger, e.Err)
+}
 
-	return &filesProcessed, &packagesDiscovered
+func (e *CancelledError) Unwrap() error {
+	return e.Err
 }
 
-func Catalog(resolver source.FileResolver, release *linux.Release, catalogers ...Cataloger) (*pkg.Catalog, []artifact.Relationship, error) {
+type catalogResult struct {
+	packages      []pkg.Package
+	relationships []artifact.Relationship
+	err           error
+}
+
+// Catalog runs all given catalogers against the resolver, running up to cfg.Parallelism catalogers (including the
+// per-package post-processing of their results) concurrently. Results are always merged in cataloger order, so the
+// catalog and relationships are the same regardless of the parallelism. Catalogers that are cancelled or time out are
+// reported as a CancelledError.
+func Catalog(ctx context.Context, resolver source.FileResolver, release *linux.Release, cfg Config, catalogers ...Cataloger) (*pkg.Catalog, []artifact.Relationship, error) {
 	catalog := pkg.NewCatalog()
 	var allRelationships []artifact.Relationship
 
 	filesProcessed, packagesDiscovered := newMonitor()
 
-	var errs error
-	for _, c := range catalogers {
+	bus.Publish(partybus.Event{
+		Type:   event.PackageCatalogerStarted,
+		Source: resolver,
+		Value: Monitor{
+			FilesProcessed:     filesProcessed,
+			PackagesDiscovered: packagesDiscovered,
+		},
+	})
 
-		log.Debugf("cataloging with %q", c.Name())
-		packages, relationships, err := c.Catalog(resolver)
-		if err != nil {
-			errs = multierror.Append(errs, err)
-			continue
-		}
-
-		catalogedPackages := len(packages)
+	resolver = monitoredResolver{FileResolver: resolver, filesProcessed: filesProcessed}
 
-		log.Debugf("discovered %d packages", catalogedPackages)
-		packagesDiscovered.N += int64(catalogedPackages)
+	parallelism := cfg.Parallelism
+	if parallelism < 1 {
+		parallelism = 1
+	}
+	if parallelism > len(catalogers) {
+		parallelism = len(catalogers)
+	}
 
-		for _, p := range packages {
+	results := make([]catalogResult, len(catalogers))
+	indexes := make(chan int)
 
-			p.CPEs = cpe.Generate(p)
+	var wg sync.WaitGroup
+	for w := 0; w < parallelism; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				name := catalogers[i].Name()
+				bus.Publish(partybus.Event{
+					Type:   event.CatalogerStarted,
+					Source: name,
+				})
 
-			p.PURL = pkg.URL(p, release)
+				results[i] = runCataloger(ctx, catalogers[i], cfg.timeoutFor(name), resolver, release)
+				packagesDiscovered.Add(int64(len(results[i].packages)))
 
-			if p.Language == "" {
-				p.Language = pkg.LanguageFromPURL(p.PURL)
+				bus.Publish(partybus.Event{
+					Type:   event.CatalogerFinished,
+					Source: name,
+					Value:  len(results[i].packages),
+					Error:  results[i].err,
+				})
 			}
+		}()
+	}
 
-			owningRelationships, err := packageFileOwnershipRelationships(p, resolver)
-			if err != nil {
-				log.Warnf("unable to create any package-file relationships for package name=%q: %w", p.Name, err)
-			} else {
-				allRelationships = append(allRelationships, owningRelationships...)
-			}
+	for i := range catalogers {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
 
+	// python packages installed by conda are already described by the conda package owning their files
+	overlapping := condaOwnedPythonPackages(results)
+
+	var errs error
+	for _, r := range results {
+		if r.err != nil {
+			errs = multierror.Append(errs, r.err)
+			continue
+		}
+
+		for _, p := range r.packages {
+			if overlapping[p.ID()] {
+				continue
+			}
 			catalog.Add(p)
 		}
 
-		allRelationships = append(allRelationships, relationships...)
+		for _, relationship := range r.relationships {
+			if overlapping[relationship.From.ID()] || overlapping[relationship.To.ID()] {
+				continue
+			}
+			allRelationships = append(allRelationships, relationship)
+		}
 	}
 
 	allRelationships = append(allRelationships, pkg.NewRelationships(catalog)...)
 
 	if errs != nil {
+		filesProcessed.SetError(errs)
+		packagesDiscovered.SetError(errs)
 		return nil, nil, errs
 	}
 
+	sortRelationships(allRelationships)
+
 	filesProcessed.SetCompleted()
 	packagesDiscovered.SetCompleted()
 
 	return catalog, allRelationships, nil
 }
 
+func runCataloger(ctx context.Context, c Cataloger, timeout time.Duration, resolver source.FileResolver, release *linux.Release) catalogResult {
+	if err := ctx.Err(); err != nil {
+		return catalogResult{err: &CancelledError{Cataloger: c.Name(), Err: err}}
+	}
+
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	log.Debugf("cataloging with %q", c.Name())
+
+	// the cataloger honors the context (checking it between files, and between the entries of the archives it walks),
+	// so that it has stopped and cleaned up once it returns
+	packages, relationships, err := c.Catalog(ctx, resolver)
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return catalogResult{err: &CancelledError{Cataloger: c.Name(), Err: ctxErr}}
+	}
+	if err != nil {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return catalogResult{err: &CancelledError{Cataloger: c.Name(), Err: err}}
+		}
+		return catalogResult{err: err}
+	}
+
+	log.Debugf("discovered %d packages", len(packages))
+
+	var owningRelationships []artifact.Relationship
+	for i := range packages {
+		if err := ctx.Err(); err != nil {
+			return catalogResult{err: &CancelledError{Cataloger: c.Name(), Err: err}}
+		}
+
+		p := &packages[i]
+
+		p.CPEs = cpe.Generate(*p)
+
+		p.PURL = pkg.URL(*p, release)
+
+		if p.Language == "" {
+			p.Language = pkg.LanguageFromPURL(p.PURL)
+		}
+
+		owning, err := packageFileOwnershipRelationships(*p, resolver)
+		if err != nil {
+			log.Warnf("unable to create any package-file relationships for package name=%q: %w", p.Name, err)
+		} else {
+			owningRelationships = append(owningRelationships, owning...)
+		}
+	}
+
+	return catalogResult{
+		packages:      packages,
+		relationships: append(owningRelationships, relationships...),
+	}
+}
+
+// monitoredResolver counts every file opened by the catalogers as processed.
+type monitoredResolver struct {
+	source.FileResolver
+	filesProcessed *monitor.Counter
+}
+
+func (r monitoredResolver) FileContentsByLocation(location source.Location) (io.ReadCloser, error) {
+	reader, err := r.FileResolver.FileContentsByLocation(location)
+	if err == nil {
+		r.filesProcessed.Add(1)
+	}
+	return reader, err
+}
+
+// sortRelationships orders relationships by their source, destination and type, since catalogers (and the file
+// ownership resolution) may discover them in any order.
+func sortRelationships(relationships []artifact.Relationship) {
+	sort.SliceStable(relationships, func(i, j int) bool {
+		a, b := relationships[i], relationships[j]
+		if a.From.ID() != b.From.ID() {
+			return a.From.ID() < b.From.ID()
+		}
+		if a.To.ID() != b.To.ID() {
+			return a.To.ID() < b.To.ID()
+		}
+		return a.Type < b.Type
+	})
+}
+
 func packageFileOwnershipRelationships(p pkg.Package, resolver source.FilePathResolver) ([]artifact.Relationship, error) {
 	fileOwner, ok := p.Metadata.(pkg.FileOwner)
 	if !ok {
@@ -119,3 +283,46 @@ func packageFileOwnershipRelationships(p pkg.Package, resolver source.FilePathRe
 	}
 	return relationships, nil
 }
+
+// condaOwnedPythonPackages finds the python packages of which every file they were found by is owned by a conda
+// package.
+func condaOwnedPythonPackages(results []catalogResult) map[artifact.ID]bool {
+	owned := strset.New()
+	for _, r := range results {
+		for _, p := range r.packages {
+			if p.Type != pkg.CondaPkg {
+				continue
+			}
+			if fileOwner, ok := p.Metadata.(pkg.FileOwner); ok {
+				owned.Add(fileOwner.OwnedFiles()...)
+			}
+		}
+	}
+
+	overlapping := make(map[artifact.ID]bool)
+	if owned.IsEmpty() {
+		return overlapping
+	}
+	for _, r := range results {
+		for _, p := range r.packages {
+			if p.Type != pkg.PythonPkg {
+				continue
+			}
+			locations := p.Locations.ToSlice()
+			if len(locations) == 0 {
+				continue
+			}
+			all := true
+			for _, location := range locations {
+				if !owned.Has(location.RealPath) {
+					all = false
+					break
+				}
+			}
+			if all {
+				overlapping[p.ID()] = true
+			}
+		}
+	}
+	return overlapping
+}
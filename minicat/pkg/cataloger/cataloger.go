@@ -6,6 +6,7 @@ catalogers defined in child packages as well as the interface definition to impl
 package cataloger
 
 import (
+	"context"
 	"strings"
 
 	"github.com/lovewebshell/minicat/internal/log"
@@ -13,12 +14,21 @@ import (
 	"github.com/lovewebshell/minicat/minicat/pkg"
 	"github.com/lovewebshell/minicat/minicat/pkg/cataloger/alpm"
 	"github.com/lovewebshell/minicat/minicat/pkg/cataloger/apkdb"
+	"github.com/lovewebshell/minicat/minicat/pkg/cataloger/conda"
+	"github.com/lovewebshell/minicat/minicat/pkg/cataloger/dart"
 	"github.com/lovewebshell/minicat/minicat/pkg/cataloger/deb"
+	"github.com/lovewebshell/minicat/minicat/pkg/cataloger/dotnet"
+	"github.com/lovewebshell/minicat/minicat/pkg/cataloger/elixir"
 	"github.com/lovewebshell/minicat/minicat/pkg/cataloger/golang"
 	"github.com/lovewebshell/minicat/minicat/pkg/cataloger/java"
 	"github.com/lovewebshell/minicat/minicat/pkg/cataloger/javascript"
+	"github.com/lovewebshell/minicat/minicat/pkg/cataloger/php"
 	"github.com/lovewebshell/minicat/minicat/pkg/cataloger/python"
 	"github.com/lovewebshell/minicat/minicat/pkg/cataloger/rpm"
+	"github.com/lovewebshell/minicat/minicat/pkg/cataloger/ruby"
+	"github.com/lovewebshell/minicat/minicat/pkg/cataloger/rust"
+	"github.com/lovewebshell/minicat/minicat/pkg/cataloger/swift"
+	"github.com/lovewebshell/minicat/minicat/pkg/cataloger/windows"
 	"github.com/lovewebshell/minicat/minicat/source"
 )
 
@@ -27,51 +37,90 @@ const AllCatalogersPattern = "all"
 type Cataloger interface {
 	Name() string
 
-	Catalog(resolver source.FileResolver) ([]pkg.Package, []artifact.Relationship, error)
+	Catalog(ctx context.Context, resolver source.FileResolver) ([]pkg.Package, []artifact.Relationship, error)
 }
 
 func ImageCatalogers(cfg Config) []Cataloger {
 	return filterCatalogers([]Cataloger{
 		alpm.NewAlpmdbCataloger(),
+		ruby.NewGemSpecCataloger(),
 		python.NewPythonPackageCataloger(),
+		conda.NewCondaMetaCataloger(),
 		javascript.NewJavascriptPackageCataloger(),
+		php.NewPHPComposerInstalledCataloger(),
+		dotnet.NewDotnetDepsCataloger(),
 		deb.NewDpkgdbCataloger(),
 		rpm.NewRpmdbCataloger(),
 		java.NewJavaCataloger(cfg.Java()),
+		java.NewJavaJVMCataloger(),
 		apkdb.NewApkdbCataloger(),
+		golang.NewGoModuleBinaryCataloger(),
+		rust.NewAuditBinaryCataloger(),
+		windows.NewKbCataloger(),
 	}, cfg.Catalogers)
 }
 
 func DirectoryCatalogers(cfg Config) []Cataloger {
 	return filterCatalogers([]Cataloger{
 		alpm.NewAlpmdbCataloger(),
+		ruby.NewGemFileLockCataloger(),
 		python.NewPythonIndexCataloger(),
 		python.NewPythonPackageCataloger(),
+		conda.NewCondaMetaCataloger(),
 		javascript.NewJavascriptLockCataloger(),
+		php.NewPHPComposerLockCataloger(),
+		dotnet.NewDotnetDepsCataloger(),
+		dotnet.NewDotnetPackagesLockCataloger(),
 		deb.NewDpkgdbCataloger(),
 		rpm.NewRpmdbCataloger(),
 		rpm.NewFileCataloger(),
 		java.NewJavaCataloger(cfg.Java()),
-		java.NewJavaPomCataloger(),
+		java.NewJavaJVMCataloger(),
+		java.NewJavaPomCataloger(cfg.Java()),
+		java.NewJavaGradleCataloger(),
 		apkdb.NewApkdbCataloger(),
 		golang.NewGoModFileCataloger(),
+		golang.NewGoModuleBinaryCataloger(),
+		rust.NewCargoLockCataloger(),
+		swift.NewCocoapodsCataloger(),
+		swift.NewSwiftPackageManagerCataloger(),
+		dart.NewPubspecLockCataloger(),
+		elixir.NewMixLockCataloger(),
+		windows.NewKbCataloger(),
 	}, cfg.Catalogers)
 }
 
 func AllCatalogers(cfg Config) []Cataloger {
 	return filterCatalogers([]Cataloger{
 		alpm.NewAlpmdbCataloger(),
+		ruby.NewGemFileLockCataloger(),
+		ruby.NewGemSpecCataloger(),
 		python.NewPythonIndexCataloger(),
 		python.NewPythonPackageCataloger(),
+		conda.NewCondaMetaCataloger(),
 		javascript.NewJavascriptLockCataloger(),
 		javascript.NewJavascriptPackageCataloger(),
+		php.NewPHPComposerLockCataloger(),
+		php.NewPHPComposerInstalledCataloger(),
+		dotnet.NewDotnetDepsCataloger(),
+		dotnet.NewDotnetPackagesLockCataloger(),
 		deb.NewDpkgdbCataloger(),
 		rpm.NewRpmdbCataloger(),
 		rpm.NewFileCataloger(),
 		java.NewJavaCataloger(cfg.Java()),
-		java.NewJavaPomCataloger(),
+		java.NewJavaJVMCataloger(),
+		java.NewJavaPomCataloger(cfg.Java()),
+		java.NewJavaGradleCataloger(),
 		apkdb.NewApkdbCataloger(),
 		golang.NewGoModFileCataloger(),
+		golang.NewGoModuleBinaryCataloger(),
+		rust.NewCargoLockCataloger(),
+		rust.NewAuditBinaryCataloger(),
+		swift.NewCocoapodsCataloger(),
+		swift.NewSwiftPackageManagerCataloger(),
+		dart.NewPubspecLockCataloger(),
+		elixir.NewMixLockCataloger(),
+		windows.NewKbCataloger(),
 	}, cfg.Catalogers)
 }
 
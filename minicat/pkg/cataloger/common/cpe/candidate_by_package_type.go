@@ -116,6 +116,17 @@ var defaultCandidateAdditions = buildCandidateLookup(
 			candidateKey{PkgName: "python-rrdtool"},
 			candidateAddition{AdditionalProducts: []string{"rrdtool"}},
 		},
+
+		{
+			pkg.RustPkg,
+			candidateKey{PkgName: "regex"},
+			candidateAddition{AdditionalVendors: []string{"rust-lang"}},
+		},
+		{
+			pkg.RustPkg,
+			candidateKey{PkgName: "openssl"},
+			candidateAddition{AdditionalVendors: []string{"rust-openssl_project"}},
+		},
 	})
 
 func buildCandidateLookup(cc []candidateComposite) (ca map[pkg.Type]map[candidateKey]candidateAddition) {
@@ -27,6 +27,10 @@ func newCPE(product, vendor, version, targetSW string) *wfn.Attributes {
 }
 
 func Generate(p pkg.Package) []pkg.CPE {
+	if m, ok := p.Metadata.(pkg.JavaVMInstallationMetadata); ok {
+		return candidateCPEsForJavaVM(m)
+	}
+
 	vendors := candidateVendors(p)
 	products := candidateProducts(p)
 	if len(products) == 0 {
@@ -76,6 +80,10 @@ func candidateVendors(p pkg.Package) []string {
 	switch p.Language {
 	case pkg.JavaScript:
 		vendors.addValue(wfn.Any)
+	case pkg.Rust:
+		vendors.union(candidateVendorsForRust(p))
+	case pkg.PHP:
+		vendors.union(candidateVendorsForPHP(p))
 	}
 
 	switch p.MetadataType {
@@ -85,7 +93,7 @@ func candidateVendors(p pkg.Package) []string {
 		vendors.union(candidateVendorsForRuby(p))
 	case pkg.PythonPackageMetadataType:
 		vendors.union(candidateVendorsForPython(p))
-	case pkg.JavaMetadataType:
+	case pkg.JavaMetadataType, pkg.GradleMetadataType:
 		vendors.union(candidateVendorsForJava(p))
 	}
 
@@ -108,7 +116,14 @@ func candidateProducts(p pkg.Package) []string {
 		if !strings.HasPrefix(p.Name, "python") {
 			products.addValue("python-" + p.Name)
 		}
-	case p.Language == pkg.Java || p.MetadataType == pkg.JavaMetadataType:
+	case p.Language == pkg.Rust:
+		if !strings.HasPrefix(p.Name, "rust-") {
+			products.addValue("rust-" + p.Name)
+		}
+	case p.Language == pkg.PHP:
+		products.clear()
+		products.addValue(candidateProductsForPHP(p)...)
+	case p.Language == pkg.Java || p.MetadataType == pkg.JavaMetadataType || p.MetadataType == pkg.GradleMetadataType:
 		products.addValue(candidateProductsForJava(p)...)
 	case p.Language == pkg.Go:
 
@@ -154,16 +154,19 @@ func productsFromArtifactAndGroupIDs(artifactID string, groupIDs []string) []str
 }
 
 func artifactIDFromJavaPackage(p pkg.Package) string {
-	metadata, ok := p.Metadata.(pkg.JavaMetadata)
-	if !ok {
-		return ""
-	}
-
-	if metadata.PomProperties == nil {
+	var artifactID string
+	switch metadata := p.Metadata.(type) {
+	case pkg.JavaMetadata:
+		if metadata.PomProperties == nil {
+			return ""
+		}
+		artifactID = strings.TrimSpace(metadata.PomProperties.ArtifactID)
+	case pkg.GradleMetadata:
+		artifactID = strings.TrimSpace(metadata.ArtifactID)
+	default:
 		return ""
 	}
 
-	artifactID := strings.TrimSpace(metadata.PomProperties.ArtifactID)
 	if startsWithTopLevelDomain(artifactID) && len(strings.Split(artifactID, ".")) > 1 {
 
 		return ""
@@ -172,6 +175,10 @@ func artifactIDFromJavaPackage(p pkg.Package) string {
 }
 
 func GroupIDsFromJavaPackage(p pkg.Package) (groupIDs []string) {
+	if metadata, ok := p.Metadata.(pkg.GradleMetadata); ok {
+		return addGroupIDsFromGroupIDsAndArtifactID(metadata.GroupID, metadata.ArtifactID)
+	}
+
 	metadata, ok := p.Metadata.(pkg.JavaMetadata)
 	if !ok {
 		return nil
@@ -0,0 +1,32 @@
+package cpe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lovewebshell/minicat/minicat/pkg"
+)
+
+func TestGenerate_GradlePackage(t *testing.T) {
+	p := pkg.Package{
+		Name:         "guava",
+		Version:      "31.1-jre",
+		Language:     pkg.Gradle,
+		Type:         pkg.JavaPkg,
+		MetadataType: pkg.GradleMetadataType,
+		Metadata: pkg.GradleMetadata{
+			GroupID:    "com.google.guava",
+			ArtifactID: "guava",
+			Version:    "31.1-jre",
+		},
+	}
+
+	var cpes []string
+	for _, c := range Generate(p) {
+		cpes = append(cpes, pkg.CPEString(c))
+	}
+
+	assert.Contains(t, cpes, "cpe:2.3:a:google:guava:31.1-jre:*:*:*:*:*:*:*")
+	assert.Equal(t, []string{"com.google.guava"}, GroupIDsFromJavaPackage(p))
+}
@@ -0,0 +1,58 @@
+package cpe
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/facebookincubator/nvdtools/wfn"
+
+	"github.com/lovewebshell/minicat/minicat/pkg"
+)
+
+// candidateCPEsForJavaVM names a JVM installation the way the NVD does: Java 8 and earlier are versioned as "1.8.0"
+// with the update as "update_372", and builds of OpenJDK by any vendor are affected by the vulnerabilities of
+// oracle:openjdk, in addition to the ones of the distribution itself.
+func candidateCPEsForJavaVM(m pkg.JavaVMInstallationMetadata) []pkg.CPE {
+	version, update := javaVMCPEVersion(m.JavaVersion)
+
+	type candidate struct {
+		vendor, product, version, update string
+	}
+	candidates := []candidate{{m.Vendor, m.Product, version, update}}
+	switch m.Product {
+	case "graalvm":
+		if m.GraalVMVersion != "" {
+			candidates[0].version, candidates[0].update = m.GraalVMVersion, wfn.Any
+		}
+		candidates = append(candidates, candidate{"oracle", "openjdk", version, update})
+	case "jdk", "jre":
+		candidates = append(candidates, candidate{"oracle", "java_se", version, update})
+	case "temurin", "zulu", "corretto":
+		candidates = append(candidates, candidate{"oracle", "openjdk", version, update})
+	}
+
+	var cpes []pkg.CPE
+	for _, c := range candidates {
+		if c.version == "" {
+			continue
+		}
+		cpe := newCPE(c.product, c.vendor, c.version, wfn.Any)
+		if cpe == nil {
+			continue
+		}
+		cpe.Update = c.update
+		cpes = append(cpes, *cpe)
+	}
+
+	sort.Sort(pkg.CPEBySpecificity(cpes))
+
+	return cpes
+}
+
+func javaVMCPEVersion(javaVersion string) (string, string) {
+	version, update, ok := strings.Cut(javaVersion, "_")
+	if !ok || update == "" {
+		return javaVersion, wfn.Any
+	}
+	return version, "update_" + update
+}
@@ -0,0 +1,34 @@
+package cpe
+
+import (
+	"strings"
+
+	"github.com/lovewebshell/minicat/minicat/pkg"
+)
+
+// splitComposerName splits a composer package name of the form "vendor/project".
+func splitComposerName(name string) (string, string) {
+	fields := strings.SplitN(name, "/", 2)
+	if len(fields) != 2 {
+		return "", name
+	}
+	return fields[0], fields[1]
+}
+
+func candidateVendorsForPHP(p pkg.Package) fieldCandidateSet {
+	vendors := newFieldCandidateSet()
+
+	if vendor, _ := splitComposerName(p.Name); vendor != "" {
+		vendors.add(fieldCandidate{
+			value:                 vendor,
+			disallowSubSelections: true,
+		})
+	}
+
+	return vendors
+}
+
+func candidateProductsForPHP(p pkg.Package) []string {
+	_, product := splitComposerName(p.Name)
+	return []string{product}
+}
@@ -0,0 +1,15 @@
+package cpe
+
+import "github.com/lovewebshell/minicat/minicat/pkg"
+
+func candidateVendorsForRust(p pkg.Package) fieldCandidateSet {
+	vendors := newFieldCandidateSet()
+
+	// crates without a known vendor are usually tracked by NVD under a "<crate>_project" vendor
+	vendors.add(fieldCandidate{
+		value:                 p.Name + "_project",
+		disallowSubSelections: true,
+	})
+
+	return vendors
+}
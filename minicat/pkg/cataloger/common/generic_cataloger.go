@@ -4,6 +4,7 @@ Package common provides generic utilities used by multiple catalogers.
 package common
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/lovewebshell/minicat/internal"
@@ -35,18 +36,21 @@ func (c *GenericCataloger) Name() string {
 	return c.upstreamCataloger
 }
 
-func (c *GenericCataloger) Catalog(resolver source.FileResolver) ([]pkg.Package, []artifact.Relationship, error) {
+func (c *GenericCataloger) Catalog(ctx context.Context, resolver source.FileResolver) ([]pkg.Package, []artifact.Relationship, error) {
 	var packages []pkg.Package
 	var relationships []artifact.Relationship
 
-	for location, parser := range c.selectFiles(resolver) {
+	for location, parser := range c.selectFiles(ctx, resolver) {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
 		contentReader, err := resolver.FileContentsByLocation(location)
 		if err != nil {
 
 			return nil, nil, fmt.Errorf("unable to fetch contents at location=%v: %w", location, err)
 		}
 
-		discoveredPackages, discoveredRelationships, err := parser(location.RealPath, contentReader)
+		discoveredPackages, discoveredRelationships, err := parser(ctx, location.RealPath, contentReader)
 		internal.CloseAndLogError(contentReader, location.VirtualPath)
 		if err != nil {
 
@@ -100,7 +104,7 @@ func removeRelationshipsWithArtifactIDs(artifactsToExclude map[artifact.ID]struc
 	return cleanedRelationships
 }
 
-func (c *GenericCataloger) selectFiles(resolver source.FilePathResolver) map[source.Location]ParserFn {
+func (c *GenericCataloger) selectFiles(ctx context.Context, resolver source.FilePathResolver) map[source.Location]ParserFn {
 	var parserByLocation = make(map[source.Location]ParserFn)
 
 	for path, parser := range c.pathParsers {
@@ -114,7 +118,7 @@ func (c *GenericCataloger) selectFiles(resolver source.FilePathResolver) map[sou
 	}
 
 	for globPattern, parser := range c.globParsers {
-		fileMatches, err := resolver.FilesByGlob(globPattern)
+		fileMatches, err := resolver.FilesByGlob(ctx, globPattern)
 		if err != nil {
 			log.Warnf("failed to find files by glob: %s", globPattern)
 		}
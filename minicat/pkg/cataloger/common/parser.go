@@ -1,10 +1,13 @@
 package common
 
 import (
+	"context"
 	"io"
 
 	"github.com/lovewebshell/minicat/minicat/artifact"
 	"github.com/lovewebshell/minicat/minicat/pkg"
 )
 
-type ParserFn func(string, io.Reader) ([]*pkg.Package, []artifact.Relationship, error)
+// ParserFn parses the packages out of the file at the given path. The context is done when cataloging is cancelled or
+// times out, which parsers that take long (such as the ones walking archives) are expected to honor.
+type ParserFn func(context.Context, string, io.Reader) ([]*pkg.Package, []artifact.Relationship, error)
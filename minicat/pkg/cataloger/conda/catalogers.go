@@ -0,0 +1,17 @@
+/*
+Package conda provides a concrete Cataloger implementation for packages installed into conda environments.
+*/
+package conda
+
+import (
+	"github.com/lovewebshell/minicat/minicat/pkg"
+	"github.com/lovewebshell/minicat/minicat/pkg/cataloger/common"
+)
+
+func NewCondaMetaCataloger() *common.GenericCataloger {
+	globParsers := map[string]common.ParserFn{
+		pkg.CondaMetaGlob: parseCondaMeta,
+	}
+
+	return common.NewGenericCataloger(nil, globParsers, "conda-meta-cataloger")
+}
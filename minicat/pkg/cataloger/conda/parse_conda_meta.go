@@ -0,0 +1,91 @@
+package conda
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+
+	"github.com/lovewebshell/minicat/minicat/artifact"
+	"github.com/lovewebshell/minicat/minicat/pkg"
+	"github.com/lovewebshell/minicat/minicat/pkg/cataloger/common"
+)
+
+var _ common.ParserFn = parseCondaMeta
+
+type condaMetaRecord struct {
+	Name        string   `json:"name"`
+	Version     string   `json:"version"`
+	Build       string   `json:"build"`
+	BuildNumber int      `json:"build_number"`
+	Channel     string   `json:"channel"`
+	Subdir      string   `json:"subdir"`
+	Filename    string   `json:"fn"`
+	MD5         string   `json:"md5"`
+	SHA256      string   `json:"sha256"`
+	License     string   `json:"license"`
+	Depends     []string `json:"depends"`
+	Files       []string `json:"files"`
+	PathsData   struct {
+		Paths []struct {
+			Path string `json:"_path"`
+		} `json:"paths"`
+	} `json:"paths_data"`
+}
+
+// parseCondaMeta reads a package record of the conda-meta directory of an environment, which lists the files of the
+// package relative to the environment (the parent of conda-meta).
+func parseCondaMeta(_ context.Context, realPath string, reader io.Reader) ([]*pkg.Package, []artifact.Relationship, error) {
+	var record condaMetaRecord
+	if err := json.NewDecoder(reader).Decode(&record); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse conda-meta record: %w", err)
+	}
+
+	if record.Name == "" || record.Version == "" {
+		return nil, nil, nil
+	}
+
+	files := record.Files
+	if len(files) == 0 {
+		for _, p := range record.PathsData.Paths {
+			files = append(files, p.Path)
+		}
+	}
+
+	prefix := path.Dir(path.Dir(realPath))
+	ownedFiles := make([]string, 0, len(files))
+	for _, f := range files {
+		if f != "" {
+			ownedFiles = append(ownedFiles, path.Join(prefix, f))
+		}
+	}
+
+	var licenses []string
+	if record.License != "" {
+		licenses = append(licenses, record.License)
+	}
+
+	return []*pkg.Package{
+		{
+			Name:         record.Name,
+			Version:      record.Version,
+			Licenses:     licenses,
+			Type:         pkg.CondaPkg,
+			MetadataType: pkg.CondaMetadataType,
+			Metadata: pkg.CondaMetadata{
+				Name:        record.Name,
+				Version:     record.Version,
+				Build:       record.Build,
+				BuildNumber: record.BuildNumber,
+				Channel:     record.Channel,
+				Subdir:      record.Subdir,
+				Filename:    record.Filename,
+				MD5:         record.MD5,
+				SHA256:      record.SHA256,
+				Depends:     record.Depends,
+				Files:       ownedFiles,
+			},
+		},
+	}, nil, nil
+}
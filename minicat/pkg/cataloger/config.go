@@ -1,17 +1,31 @@
 package cataloger
 
 import (
+	"time"
+
 	"github.com/lovewebshell/minicat/minicat/pkg/cataloger/java"
 )
 
 type Config struct {
-	Search     SearchConfig
-	Catalogers []string
+	Search      SearchConfig
+	Catalogers  []string
+	Parallelism int
+	// Timeout bounds the run of every cataloger (zero means no limit), while CatalogerTimeouts overrides it for the
+	// catalogers with the given names.
+	Timeout           time.Duration
+	CatalogerTimeouts map[string]time.Duration
+	// MavenLocalRepository is a directory laid out like ~/.m2/repository, where the parent and imported POMs of the
+	// pom.xml files found are looked up when they are not among the scanned files.
+	MavenLocalRepository string
+	// JavaArchiveLimits bound the archives extracted while scanning Java archives and the archives nested in them.
+	JavaArchiveLimits java.ArchiveLimits
 }
 
 func DefaultConfig() Config {
 	return Config{
-		Search: DefaultSearchConfig(),
+		Search:            DefaultSearchConfig(),
+		Parallelism:       1,
+		JavaArchiveLimits: java.DefaultArchiveLimits(),
 	}
 }
 
@@ -19,5 +33,14 @@ func (c Config) Java() java.Config {
 	return java.Config{
 		SearchUnindexedArchives: c.Search.IncludeUnindexedArchives,
 		SearchIndexedArchives:   c.Search.IncludeIndexedArchives,
+		MavenLocalRepository:    c.MavenLocalRepository,
+		ArchiveLimits:           c.JavaArchiveLimits,
+	}
+}
+
+func (c Config) timeoutFor(catalogerName string) time.Duration {
+	if timeout, ok := c.CatalogerTimeouts[catalogerName]; ok {
+		return timeout
 	}
+	return c.Timeout
 }
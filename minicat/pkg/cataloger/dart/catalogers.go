@@ -0,0 +1,16 @@
+/*
+Package dart provides a concrete Cataloger implementation for Dart and Flutter pubspec.lock files.
+*/
+package dart
+
+import (
+	"github.com/lovewebshell/minicat/minicat/pkg/cataloger/common"
+)
+
+func NewPubspecLockCataloger() *common.GenericCataloger {
+	globParsers := map[string]common.ParserFn{
+		"**/pubspec.lock": parsePubspecLock,
+	}
+
+	return common.NewGenericCataloger(nil, globParsers, "dart-pubspec-lock-cataloger")
+}
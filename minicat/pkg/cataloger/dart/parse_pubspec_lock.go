@@ -0,0 +1,110 @@
+package dart
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/lovewebshell/minicat/minicat/artifact"
+	"github.com/lovewebshell/minicat/minicat/pkg"
+)
+
+const defaultPubRegistry = "https://pub.dartlang.org"
+
+type pubspecLock struct {
+	Packages map[string]pubspecLockPackage `yaml:"packages"`
+}
+
+type pubspecLockPackage struct {
+	Dependency  string                 `yaml:"dependency"`
+	Description pubspecLockDescription `yaml:"description"`
+	Source      string                 `yaml:"source"`
+	Version     string                 `yaml:"version"`
+}
+
+// pubspecLockDescription is either a plain string (for sdk packages) or a mapping describing where the package comes
+// from.
+type pubspecLockDescription struct {
+	Name        string `yaml:"name"`
+	URL         string `yaml:"url"`
+	Path        string `yaml:"path"`
+	Ref         string `yaml:"ref"`
+	ResolvedRef string `yaml:"resolved-ref"`
+}
+
+func (d *pubspecLockDescription) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		d.Name = value.Value
+		return nil
+	}
+	type description pubspecLockDescription
+	return value.Decode((*description)(d))
+}
+
+func parsePubspecLock(_ context.Context, _ string, reader io.Reader) ([]*pkg.Package, []artifact.Relationship, error) {
+	var lock pubspecLock
+	if err := yaml.NewDecoder(reader).Decode(&lock); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse pubspec.lock file: %w", err)
+	}
+
+	names := make([]string, 0, len(lock.Packages))
+	for name := range lock.Packages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var pkgs []*pkg.Package
+	for _, name := range names {
+		p := lock.Packages[name]
+		// sdk packages (flutter, flutter_test, ...) ship with the sdk itself and have no version of their own
+		if p.Source == "sdk" || p.Version == "" {
+			continue
+		}
+
+		pkgs = append(pkgs, &pkg.Package{
+			Name:         name,
+			Version:      p.Version,
+			Language:     pkg.Dart,
+			Type:         pkg.DartPubPkg,
+			MetadataType: pkg.DartPubMetadataType,
+			Metadata: pkg.DartPubMetadata{
+				Name:      name,
+				Version:   p.Version,
+				HostedURL: p.hostedURL(),
+				VcsURL:    p.vcsURL(),
+			},
+		})
+	}
+
+	return pkgs, nil, nil
+}
+
+// hostedURL is the host of a package from a registry other than the default one.
+func (p pubspecLockPackage) hostedURL() string {
+	if p.Source != "hosted" || p.Description.URL == "" {
+		return ""
+	}
+	if p.Description.URL == defaultPubRegistry || p.Description.URL == "https://pub.dev" {
+		return ""
+	}
+	u, err := url.Parse(p.Description.URL)
+	if err != nil || u.Host == "" {
+		return p.Description.URL
+	}
+	return u.Host + u.Path
+}
+
+func (p pubspecLockPackage) vcsURL() string {
+	if p.Source != "git" || p.Description.URL == "" {
+		return ""
+	}
+	vcsURL := p.Description.URL
+	if p.Description.ResolvedRef != "" {
+		vcsURL += "@" + p.Description.ResolvedRef
+	}
+	return vcsURL
+}
@@ -4,6 +4,7 @@ Package dpkg provides a concrete Cataloger implementation for Debian package DB
 package deb
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"path"
@@ -33,14 +34,17 @@ func (c *Cataloger) Name() string {
 	return "dpkgdb-cataloger"
 }
 
-func (c *Cataloger) Catalog(resolver source.FileResolver) ([]pkg.Package, []artifact.Relationship, error) {
-	dbFileMatches, err := resolver.FilesByGlob(pkg.DpkgDBGlob)
+func (c *Cataloger) Catalog(ctx context.Context, resolver source.FileResolver) ([]pkg.Package, []artifact.Relationship, error) {
+	dbFileMatches, err := resolver.FilesByGlob(ctx, pkg.DpkgDBGlob)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to find dpkg status files's by glob: %w", err)
 	}
 
 	var allPackages []pkg.Package
 	for _, dbLocation := range dbFileMatches {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
 		dbContents, err := resolver.FileContentsByLocation(dbLocation)
 		if err != nil {
 			return nil, nil, err
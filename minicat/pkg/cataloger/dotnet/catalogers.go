@@ -0,0 +1,37 @@
+/*
+Package dotnet provides concrete Cataloger implementations for .NET ecosystem files (the *.deps.json written next to
+built assemblies and NuGet packages.lock.json files).
+*/
+package dotnet
+
+import (
+	"github.com/lovewebshell/minicat/minicat/pkg"
+	"github.com/lovewebshell/minicat/minicat/pkg/cataloger/common"
+)
+
+func NewDotnetDepsCataloger() *common.GenericCataloger {
+	globParsers := map[string]common.ParserFn{
+		"**/*.deps.json": parseDotnetDeps,
+	}
+
+	return common.NewGenericCataloger(nil, globParsers, "dotnet-deps-cataloger")
+}
+
+func NewDotnetPackagesLockCataloger() *common.GenericCataloger {
+	globParsers := map[string]common.ParserFn{
+		"**/packages.lock.json": parsePackagesLock,
+	}
+
+	return common.NewGenericCataloger(nil, globParsers, "dotnet-packages-lock-cataloger")
+}
+
+func newNugetPackage(m pkg.DotnetDepsMetadata) *pkg.Package {
+	return &pkg.Package{
+		Name:         m.Name,
+		Version:      m.Version,
+		Language:     pkg.Dotnet,
+		Type:         pkg.NugetPkg,
+		MetadataType: pkg.DotnetDepsMetadataType,
+		Metadata:     m,
+	}
+}
@@ -0,0 +1,99 @@
+package dotnet
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/lovewebshell/minicat/minicat/artifact"
+	"github.com/lovewebshell/minicat/minicat/pkg"
+)
+
+type dotnetDeps struct {
+	RuntimeTarget dotnetRuntimeTarget                    `json:"runtimeTarget"`
+	Targets       map[string]map[string]dotnetDepsTarget `json:"targets"`
+	Libraries     map[string]dotnetDepsLibrary           `json:"libraries"`
+}
+
+type dotnetRuntimeTarget struct {
+	Name string `json:"name"`
+}
+
+type dotnetDepsTarget struct {
+	Dependencies map[string]string `json:"dependencies"`
+}
+
+type dotnetDepsLibrary struct {
+	Type     string `json:"type"`
+	Path     string `json:"path"`
+	Sha512   string `json:"sha512"`
+	HashPath string `json:"hashPath"`
+}
+
+func parseDotnetDeps(_ context.Context, _ string, reader io.Reader) ([]*pkg.Package, []artifact.Relationship, error) {
+	var deps dotnetDeps
+	if err := json.NewDecoder(reader).Decode(&deps); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse deps.json file: %w", err)
+	}
+
+	// libraries are keyed by "name/version"
+	keys := make([]string, 0, len(deps.Libraries))
+	for key := range deps.Libraries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var pkgs []*pkg.Package
+	pkgsByKey := make(map[string]*pkg.Package)
+	for _, key := range keys {
+		fields := strings.SplitN(key, "/", 2)
+		if len(fields) != 2 || fields[0] == "" || fields[1] == "" {
+			continue
+		}
+		lib := deps.Libraries[key]
+		// project libraries are the application itself and the projects it references, which are not NuGet packages
+		if !strings.EqualFold(lib.Type, "package") {
+			continue
+		}
+		p := newNugetPackage(pkg.DotnetDepsMetadata{
+			Name:     fields[0],
+			Version:  fields[1],
+			Type:     lib.Type,
+			Path:     lib.Path,
+			Sha512:   lib.Sha512,
+			HashPath: lib.HashPath,
+		})
+		pkgs = append(pkgs, p)
+		pkgsByKey[key] = p
+	}
+
+	var relationships []artifact.Relationship
+	for targetName, target := range deps.Targets {
+		// a deps.json for a self-contained app also lists runtime specific targets, which repeat the same libraries
+		if deps.RuntimeTarget.Name != "" && targetName != deps.RuntimeTarget.Name {
+			continue
+		}
+		for key, entry := range target {
+			p, ok := pkgsByKey[key]
+			if !ok {
+				continue
+			}
+			for name, version := range entry.Dependencies {
+				dep, ok := pkgsByKey[name+"/"+version]
+				if !ok {
+					continue
+				}
+				relationships = append(relationships, artifact.Relationship{
+					From: dep,
+					To:   p,
+					Type: artifact.DependencyOfRelationship,
+				})
+			}
+		}
+	}
+
+	return pkgs, relationships, nil
+}
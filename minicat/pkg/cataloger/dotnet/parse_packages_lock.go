@@ -0,0 +1,108 @@
+package dotnet
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/lovewebshell/minicat/minicat/artifact"
+	"github.com/lovewebshell/minicat/minicat/pkg"
+)
+
+// packagesLock is a NuGet packages.lock.json, holding the resolved dependencies for each target framework (and
+// runtime identifier).
+type packagesLock struct {
+	Version      int                                     `json:"version"`
+	Dependencies map[string]map[string]packagesLockEntry `json:"dependencies"`
+}
+
+type packagesLockEntry struct {
+	Type         string            `json:"type"`
+	Requested    string            `json:"requested"`
+	Resolved     string            `json:"resolved"`
+	ContentHash  string            `json:"contentHash"`
+	Dependencies map[string]string `json:"dependencies"`
+}
+
+func parsePackagesLock(_ context.Context, _ string, reader io.Reader) ([]*pkg.Package, []artifact.Relationship, error) {
+	var lock packagesLock
+	if err := json.NewDecoder(reader).Decode(&lock); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse packages.lock.json file: %w", err)
+	}
+
+	targets := make([]string, 0, len(lock.Dependencies))
+	for target := range lock.Dependencies {
+		targets = append(targets, target)
+	}
+	sort.Strings(targets)
+
+	var pkgs []*pkg.Package
+	// the same package is usually resolved for several targets, but is only reported once
+	pkgsByID := make(map[string]*pkg.Package)
+	var relationships []artifact.Relationship
+	relationshipSeen := make(map[[2]string]bool)
+	for _, target := range targets {
+		entries := lock.Dependencies[target]
+
+		names := make([]string, 0, len(entries))
+		for name := range entries {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		resolved := make(map[string]*pkg.Package)
+		for _, name := range names {
+			entry := entries[name]
+			// project entries are the projects referenced by the project, which are not NuGet packages
+			if entry.Resolved == "" || strings.EqualFold(entry.Type, "project") {
+				continue
+			}
+			id := name + "/" + entry.Resolved
+			p, ok := pkgsByID[id]
+			if !ok {
+				var sha512 string
+				if entry.ContentHash != "" {
+					// the content hash is the base64 sha512 of the package, as recorded for libraries in deps.json files
+					sha512 = "sha512-" + entry.ContentHash
+				}
+				p = newNugetPackage(pkg.DotnetDepsMetadata{
+					Name:    name,
+					Version: entry.Resolved,
+					Type:    entry.Type,
+					Sha512:  sha512,
+				})
+				pkgsByID[id] = p
+				pkgs = append(pkgs, p)
+			}
+			resolved[name] = p
+		}
+
+		for _, name := range names {
+			p, ok := resolved[name]
+			if !ok {
+				continue
+			}
+			for depName := range entries[name].Dependencies {
+				dep, ok := resolved[depName]
+				if !ok {
+					continue
+				}
+				key := [2]string{depName + "/" + dep.Version, name + "/" + p.Version}
+				if relationshipSeen[key] {
+					continue
+				}
+				relationshipSeen[key] = true
+				relationships = append(relationships, artifact.Relationship{
+					From: dep,
+					To:   p,
+					Type: artifact.DependencyOfRelationship,
+				})
+			}
+		}
+	}
+
+	return pkgs, relationships, nil
+}
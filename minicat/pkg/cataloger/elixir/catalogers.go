@@ -0,0 +1,16 @@
+/*
+Package elixir provides a concrete Cataloger implementation for Elixir mix.lock files.
+*/
+package elixir
+
+import (
+	"github.com/lovewebshell/minicat/minicat/pkg/cataloger/common"
+)
+
+func NewMixLockCataloger() *common.GenericCataloger {
+	globParsers := map[string]common.ParserFn{
+		"**/mix.lock": parseMixLock,
+	}
+
+	return common.NewGenericCataloger(nil, globParsers, "elixir-mix-lock-cataloger")
+}
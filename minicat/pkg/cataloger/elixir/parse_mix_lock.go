@@ -0,0 +1,85 @@
+package elixir
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+
+	"github.com/lovewebshell/minicat/internal/log"
+	"github.com/lovewebshell/minicat/minicat/artifact"
+	"github.com/lovewebshell/minicat/minicat/pkg"
+)
+
+// mixLockEntry matches a hex package of a mix.lock, for example:
+//
+//	"cowboy": {:hex, :cowboy, "2.9.0", "865d...", [:make, :rebar3], [{:cowlib, "2.11.0", [hex: :cowlib, repo: "hexpm", optional: false]}], "hexpm", "2c72..."},
+//
+// Lockfiles written by older versions of mix and hex leave out the outer checksum, or the repository as well.
+var mixLockEntry = regexp.MustCompile(`^\s*"([^"]+)":\s*\{:hex,\s*:"?[^,"]+"?,\s*"([^"]+)",\s*"([^"]*)",\s*\[[^\]]*\],\s*\[(.*)\](?:,\s*"[^"]*"(?:,\s*"([^"]*)")?)?\},?\s*$`)
+
+var mixLockHexEntry = regexp.MustCompile(`^\s*"[^"]+":\s*\{:hex,`)
+
+var mixLockDependency = regexp.MustCompile(`\{:"?([^,"\s]+)"?,`)
+
+func parseMixLock(_ context.Context, _ string, reader io.Reader) ([]*pkg.Package, []artifact.Relationship, error) {
+	var pkgs []*pkg.Package
+	pkgsByName := make(map[string]*pkg.Package)
+	dependenciesByName := make(map[string][]string)
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		match := mixLockEntry.FindStringSubmatch(line)
+		if match == nil {
+			// git and path dependencies carry no version
+			if mixLockHexEntry.MatchString(line) {
+				log.Warnf("unable to parse mix.lock hex entry: %q", line)
+			}
+			continue
+		}
+		name, version, pkgHash, dependencies, pkgHashExt := match[1], match[2], match[3], match[4], match[5]
+
+		p := &pkg.Package{
+			Name:         name,
+			Version:      version,
+			Language:     pkg.Elixir,
+			Type:         pkg.HexPkg,
+			MetadataType: pkg.MixLockMetadataType,
+			Metadata: pkg.MixLockMetadata{
+				Name:       name,
+				Version:    version,
+				PkgHash:    pkgHash,
+				PkgHashExt: pkgHashExt,
+			},
+		}
+		pkgs = append(pkgs, p)
+		pkgsByName[name] = p
+
+		for _, dep := range mixLockDependency.FindAllStringSubmatch(dependencies, -1) {
+			dependenciesByName[name] = append(dependenciesByName[name], dep[1])
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse mix.lock file: %w", err)
+	}
+
+	var relationships []artifact.Relationship
+	for _, p := range pkgs {
+		for _, depName := range dependenciesByName[p.Name] {
+			dep, ok := pkgsByName[depName]
+			if !ok {
+				continue
+			}
+			relationships = append(relationships, artifact.Relationship{
+				From: dep,
+				To:   p,
+				Type: artifact.DependencyOfRelationship,
+			})
+		}
+	}
+
+	return pkgs, relationships, nil
+}
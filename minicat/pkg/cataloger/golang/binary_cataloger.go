@@ -0,0 +1,58 @@
+package golang
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lovewebshell/minicat/internal"
+	"github.com/lovewebshell/minicat/internal/log"
+	"github.com/lovewebshell/minicat/minicat/artifact"
+	"github.com/lovewebshell/minicat/minicat/pkg"
+	"github.com/lovewebshell/minicat/minicat/source"
+)
+
+const binaryCatalogerName = "go-module-binary-cataloger"
+
+type BinaryCataloger struct{}
+
+func NewGoModuleBinaryCataloger() *BinaryCataloger {
+	return &BinaryCataloger{}
+}
+
+func (c *BinaryCataloger) Name() string {
+	return binaryCatalogerName
+}
+
+func (c *BinaryCataloger) Catalog(ctx context.Context, resolver source.FileResolver) ([]pkg.Package, []artifact.Relationship, error) {
+	fileMatches, err := resolver.FilesByMIMEType(internal.ExecutableMIMETypeSet.List()...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find executables by mime type: %w", err)
+	}
+
+	var pkgs []pkg.Package
+	var relationships []artifact.Relationship
+	for _, location := range fileMatches {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+		reader, err := resolver.FileContentsByLocation(location)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		builds, err := scanBinary(reader)
+		internal.CloseAndLogError(reader, location.VirtualPath)
+		if err != nil {
+			log.Debugf("go binary cataloger: unable to read build info from file=%q: %+v", location.RealPath, err)
+			continue
+		}
+
+		for _, b := range builds {
+			discoveredPkgs, discoveredRelationships := buildPackages(b, location)
+			pkgs = append(pkgs, discoveredPkgs...)
+			relationships = append(relationships, discoveredRelationships...)
+		}
+	}
+
+	return pkgs, relationships, nil
+}
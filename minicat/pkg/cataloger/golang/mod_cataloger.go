@@ -1,16 +1,442 @@
 /*
-Package golang provides a concrete Cataloger implementation for go.mod files.
+Package golang provides concrete Cataloger implementations for go.mod files and Go binaries.
 */
 package golang
 
 import (
-	"github.com/lovewebshell/minicat/minicat/pkg/cataloger/common"
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+
+	"github.com/lovewebshell/minicat/internal"
+	"github.com/lovewebshell/minicat/internal/log"
+	"github.com/lovewebshell/minicat/minicat/artifact"
+	"github.com/lovewebshell/minicat/minicat/pkg"
+	"github.com/lovewebshell/minicat/minicat/source"
 )
 
-func NewGoModFileCataloger() *common.GenericCataloger {
-	globParsers := map[string]common.ParserFn{
-		"**/go.mod": parseGoMod,
+const modFileCatalogerName = "go-mod-file-cataloger"
+
+type ModFileCataloger struct{}
+
+func NewGoModFileCataloger() *ModFileCataloger {
+	return &ModFileCataloger{}
+}
+
+func (c *ModFileCataloger) Name() string {
+	return modFileCatalogerName
+}
+
+// Catalog resolves every go.mod file together with the go.sum and vendor/modules.txt files next to it, where the
+// modules used by a go.work file are resolved together as a single workspace. Vendor directories without a go.mod
+// file next to them are cataloged on their own.
+func (c *ModFileCataloger) Catalog(ctx context.Context, resolver source.FileResolver) ([]pkg.Package, []artifact.Relationship, error) {
+	modLocations, err := findGoFiles(ctx, resolver, "**/go.mod")
+	if err != nil {
+		return nil, nil, err
+	}
+	workLocations, err := findGoFiles(ctx, resolver, "**/go.work")
+	if err != nil {
+		return nil, nil, err
+	}
+	vendorLocations, err := findGoFiles(ctx, resolver, "**/vendor/modules.txt")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	modules := make(map[string]*goModule)
+	var dirs []string
+	for _, location := range modLocations {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+		// modules copied into a vendor directory are described by vendor/modules.txt
+		if strings.Contains(location.RealPath, "/vendor/") {
+			continue
+		}
+		m, err := readGoModule(resolver, location)
+		if err != nil {
+			log.Warnf("cataloger '%s' failed to parse entries at location=%+v: %+v", modFileCatalogerName, location, err)
+			continue
+		}
+		dir := path.Dir(location.RealPath)
+		modules[dir] = m
+		dirs = append(dirs, dir)
 	}
 
-	return common.NewGenericCataloger(nil, globParsers, "go-mod-file-cataloger")
+	var workspaces []goWorkspace
+	used := make(map[string]bool)
+	for _, location := range workLocations {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+		w, err := readGoWorkspace(resolver, location, modules)
+		if err != nil {
+			log.Warnf("cataloger '%s' failed to parse entries at location=%+v: %+v", modFileCatalogerName, location, err)
+			continue
+		}
+		for _, m := range w.modules {
+			used[path.Dir(m.location.RealPath)] = true
+		}
+		workspaces = append(workspaces, w)
+	}
+	for _, dir := range dirs {
+		if !used[dir] {
+			workspaces = append(workspaces, goWorkspace{modules: []*goModule{modules[dir]}})
+		}
+	}
+
+	var pkgs []pkg.Package
+	var relationships []artifact.Relationship
+	for _, w := range workspaces {
+		discoveredPkgs, discoveredRelationships := w.resolve()
+		pkgs = append(pkgs, discoveredPkgs...)
+		relationships = append(relationships, discoveredRelationships...)
+	}
+
+	for _, location := range vendorLocations {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+		if _, ok := modules[path.Dir(path.Dir(location.RealPath))]; ok {
+			continue
+		}
+		v, err := readGoVendor(resolver, location)
+		if err != nil {
+			log.Warnf("cataloger '%s' failed to parse entries at location=%+v: %+v", modFileCatalogerName, location, err)
+			continue
+		}
+		for _, m := range v.modules {
+			mod := m.mod
+			if m.replacement != nil && m.replacement.Version != "" {
+				mod = *m.replacement
+			}
+			p := newGoModPackage(mod, pkg.GolangModMetadata{Indirect: !m.explicit, Vendored: true}, location)
+			p.SetID()
+			pkgs = append(pkgs, p)
+		}
+	}
+
+	return pkgs, relationships, nil
+}
+
+// goWorkspace is a go.work file with the modules it uses, or a single module outside of any workspace.
+type goWorkspace struct {
+	replace []*modfile.Replace
+	sums    map[string]string
+	modules []*goModule
+}
+
+type goDependency struct {
+	mod       module.Version
+	metadata  pkg.GolangModMetadata
+	locations []source.Location
+	dependent []*goModule
+}
+
+// resolve expresses every module of the workspace and every module they require as packages, relating the
+// requirements to the modules requiring them. As far as the go.mod files tell, a requirement shared by several modules
+// of the workspace resolves to the highest version any of them requires, the way minimal version selection would.
+func (w goWorkspace) resolve() ([]pkg.Package, []artifact.Relationship) {
+	mains := make(map[string]*goModule)
+	for _, m := range w.modules {
+		if m.file.Module != nil {
+			mains[m.file.Module.Mod.Path] = m
+		}
+	}
+
+	selected := make(map[string]string)
+	for _, m := range w.modules {
+		for _, r := range m.file.Require {
+			if _, ok := mains[r.Mod.Path]; ok || excluded(m.file, r.Mod) {
+				continue
+			}
+			if current, ok := selected[r.Mod.Path]; !ok || semver.Compare(r.Mod.Version, current) > 0 {
+				selected[r.Mod.Path] = r.Mod.Version
+			}
+		}
+	}
+
+	deps := make(map[module.Version]*goDependency)
+	var mainEdges [][2]*goModule
+	for _, m := range w.modules {
+		required := make(map[string]bool)
+		for _, r := range m.file.Require {
+			if dep, ok := mains[r.Mod.Path]; ok {
+				mainEdges = append(mainEdges, [2]*goModule{dep, m})
+				continue
+			}
+			if excluded(m.file, r.Mod) {
+				continue
+			}
+			required[r.Mod.Path] = true
+			w.addDependency(deps, m, module.Version{Path: r.Mod.Path, Version: selected[r.Mod.Path]}, r.Indirect)
+		}
+
+		// vendor directories of older go versions also list the modules that are only required indirectly
+		if m.vendor != nil {
+			for _, v := range m.vendor.modules {
+				if !required[v.mod.Path] {
+					if _, ok := mains[v.mod.Path]; !ok {
+						w.addDependency(deps, m, v.mod, !v.explicit)
+					}
+				}
+			}
+		}
+	}
+
+	var pkgs []pkg.Package
+	mainPkgs := make(map[*goModule]pkg.Package)
+	for _, m := range w.modules {
+		if m.file.Module == nil {
+			continue
+		}
+		p := pkg.Package{
+			Name:      m.file.Module.Mod.Path,
+			FoundBy:   modFileCatalogerName,
+			Locations: source.NewLocationSet(m.location),
+			Language:  pkg.Go,
+			Type:      pkg.GoModulePkg,
+		}
+		p.SetID()
+		pkgs = append(pkgs, p)
+		mainPkgs[m] = p
+	}
+
+	keys := make([]module.Version, 0, len(deps))
+	for k := range deps {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Path != keys[j].Path {
+			return keys[i].Path < keys[j].Path
+		}
+		return keys[i].Version < keys[j].Version
+	})
+
+	var relationships []artifact.Relationship
+	for _, k := range keys {
+		d := deps[k]
+		p := newGoModPackage(d.mod, d.metadata, d.locations...)
+		p.SetID()
+		pkgs = append(pkgs, p)
+
+		for _, m := range d.dependent {
+			if main, ok := mainPkgs[m]; ok {
+				relationships = append(relationships, artifact.Relationship{
+					From: p,
+					To:   main,
+					Type: artifact.DependencyOfRelationship,
+				})
+			}
+		}
+	}
+
+	for _, edge := range mainEdges {
+		dep, ok := mainPkgs[edge[0]]
+		main, ok2 := mainPkgs[edge[1]]
+		if ok && ok2 {
+			relationships = append(relationships, artifact.Relationship{
+				From: dep,
+				To:   main,
+				Type: artifact.DependencyOfRelationship,
+			})
+		}
+	}
+
+	return pkgs, relationships
+}
+
+// addDependency records the given requirement of a module of the workspace, where a dependency is only indirect if it
+// is an indirect requirement of every module requiring it.
+func (w goWorkspace) addDependency(deps map[module.Version]*goDependency, m *goModule, mod module.Version, indirect bool) {
+	target := w.replacement(m, mod)
+	if target.Version == "" {
+		// replaced by a local directory, which is the module itself at no particular version
+		target = module.Version{Path: mod.Path}
+	}
+
+	d, ok := deps[target]
+	if !ok {
+		d = &goDependency{
+			mod: target,
+			metadata: pkg.GolangModMetadata{
+				H1Digest: w.sum(target),
+				Indirect: true,
+			},
+		}
+		deps[target] = d
+	}
+	d.metadata.Indirect = d.metadata.Indirect && indirect
+	d.locations = append(d.locations, m.location)
+	if m.vendor.contains(mod.Path) {
+		d.metadata.Vendored = true
+		d.locations = append(d.locations, m.vendor.location)
+	}
+	for _, dependent := range d.dependent {
+		if dependent == m {
+			return
+		}
+	}
+	d.dependent = append(d.dependent, m)
+}
+
+// replacement is the module the given module is replaced with, if any, where replacements of the workspace take
+// precedence over the ones of the module and replacements of a single version over the ones of every version.
+func (w goWorkspace) replacement(m *goModule, mod module.Version) module.Version {
+	for _, replaces := range [][]*modfile.Replace{w.replace, m.file.Replace} {
+		for _, r := range replaces {
+			if r.Old.Path == mod.Path && r.Old.Version == mod.Version {
+				return r.New
+			}
+		}
+		for _, r := range replaces {
+			if r.Old.Path == mod.Path && r.Old.Version == "" {
+				return r.New
+			}
+		}
+	}
+	return mod
+}
+
+func (w goWorkspace) sum(mod module.Version) string {
+	key := mod.Path + "@" + mod.Version
+	if h1, ok := w.sums[key]; ok {
+		return h1
+	}
+	for _, m := range w.modules {
+		if h1, ok := m.sums[key]; ok {
+			return h1
+		}
+	}
+	return ""
+}
+
+func excluded(file *modfile.File, mod module.Version) bool {
+	for _, e := range file.Exclude {
+		if e.Mod == mod {
+			return true
+		}
+	}
+	return false
+}
+
+func newGoModPackage(mod module.Version, metadata pkg.GolangModMetadata, locations ...source.Location) pkg.Package {
+	return pkg.Package{
+		Name:         mod.Path,
+		Version:      mod.Version,
+		FoundBy:      modFileCatalogerName,
+		Locations:    source.NewLocationSet(locations...),
+		Language:     pkg.Go,
+		Type:         pkg.GoModulePkg,
+		MetadataType: pkg.GolangModMetadataType,
+		Metadata:     metadata,
+	}
+}
+
+func findGoFiles(ctx context.Context, resolver source.FileResolver, glob string) ([]source.Location, error) {
+	locations, err := resolver.FilesByGlob(ctx, glob)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find files by glob: %s", glob)
+	}
+	sort.Slice(locations, func(i, j int) bool {
+		return locations[i].RealPath < locations[j].RealPath
+	})
+	return locations, nil
+}
+
+func readGoModule(resolver source.FileResolver, location source.Location) (*goModule, error) {
+	reader, err := resolver.FileContentsByLocation(location)
+	if err != nil {
+		return nil, err
+	}
+	file, err := parseGoMod(location.RealPath, reader)
+	internal.CloseAndLogError(reader, location.VirtualPath)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &goModule{location: location, file: file}
+	dir := path.Dir(location.RealPath)
+
+	if sumLocation := findGoFile(resolver, path.Join(dir, "go.sum")); sumLocation != nil {
+		m.sums, err = readGoSum(resolver, *sumLocation)
+		if err != nil {
+			log.Debugf("unable to read go.sum at location=%+v: %+v", *sumLocation, err)
+		}
+	}
+
+	if vendorLocation := findGoFile(resolver, path.Join(dir, "vendor", "modules.txt")); vendorLocation != nil {
+		m.vendor, err = readGoVendor(resolver, *vendorLocation)
+		if err != nil {
+			log.Debugf("unable to read vendor/modules.txt at location=%+v: %+v", *vendorLocation, err)
+		}
+	}
+
+	return m, nil
+}
+
+func readGoWorkspace(resolver source.FileResolver, location source.Location, modules map[string]*goModule) (goWorkspace, error) {
+	reader, err := resolver.FileContentsByLocation(location)
+	if err != nil {
+		return goWorkspace{}, err
+	}
+	file, err := parseGoWork(location.RealPath, reader)
+	internal.CloseAndLogError(reader, location.VirtualPath)
+	if err != nil {
+		return goWorkspace{}, err
+	}
+
+	w := goWorkspace{replace: file.Replace}
+	dir := path.Dir(location.RealPath)
+	for _, use := range file.Use {
+		if m, ok := modules[path.Join(dir, use.Path)]; ok {
+			w.modules = append(w.modules, m)
+		}
+	}
+
+	if sumLocation := findGoFile(resolver, path.Join(dir, "go.work.sum")); sumLocation != nil {
+		w.sums, err = readGoSum(resolver, *sumLocation)
+		if err != nil {
+			log.Debugf("unable to read go.work.sum at location=%+v: %+v", *sumLocation, err)
+		}
+	}
+
+	return w, nil
+}
+
+func readGoSum(resolver source.FileResolver, location source.Location) (map[string]string, error) {
+	reader, err := resolver.FileContentsByLocation(location)
+	if err != nil {
+		return nil, err
+	}
+	defer internal.CloseAndLogError(reader, location.VirtualPath)
+	return parseGoSum(reader)
+}
+
+func readGoVendor(resolver source.FileResolver, location source.Location) (*goVendor, error) {
+	reader, err := resolver.FileContentsByLocation(location)
+	if err != nil {
+		return nil, err
+	}
+	defer internal.CloseAndLogError(reader, location.VirtualPath)
+	modules, err := parseVendorModules(reader)
+	if err != nil {
+		return nil, err
+	}
+	return &goVendor{location: location, modules: modules}, nil
+}
+
+func findGoFile(resolver source.FileResolver, p string) *source.Location {
+	locations, err := resolver.FilesByPath(p)
+	if err != nil || len(locations) == 0 {
+		return nil
+	}
+	return &locations[0]
 }
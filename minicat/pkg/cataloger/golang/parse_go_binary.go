@@ -0,0 +1,80 @@
+package golang
+
+import (
+	"runtime/debug"
+
+	"github.com/lovewebshell/minicat/minicat/artifact"
+	"github.com/lovewebshell/minicat/minicat/pkg"
+	"github.com/lovewebshell/minicat/minicat/source"
+)
+
+// buildPackages expresses the main module and every dependency recorded in the build info as packages, where each
+// dependency is related to the main module of the binary.
+func buildPackages(b binaryBuild, location source.Location) ([]pkg.Package, []artifact.Relationship) {
+	info := b.info
+
+	mainModule := info.Main.Path
+	if mainModule == "" {
+		mainModule = info.Path
+	}
+
+	var pkgs []pkg.Package
+	var main *pkg.Package
+	if info.Main.Path != "" {
+		settings := make(map[string]string)
+		for _, s := range info.Settings {
+			settings[s.Key] = s.Value
+		}
+
+		p := newGoBinaryPackage(&info.Main, mainModule, info.GoVersion, b.arch, location)
+		if m, ok := p.Metadata.(pkg.GolangBinMetadata); ok && len(settings) > 0 {
+			m.BuildSettings = settings
+			p.Metadata = m
+		}
+		p.SetID()
+		main = &p
+		pkgs = append(pkgs, p)
+	}
+
+	var relationships []artifact.Relationship
+	for _, dep := range info.Deps {
+		if dep == nil {
+			continue
+		}
+		p := newGoBinaryPackage(dep, mainModule, info.GoVersion, b.arch, location)
+		p.SetID()
+		pkgs = append(pkgs, p)
+
+		if main != nil {
+			relationships = append(relationships, artifact.Relationship{
+				From: p,
+				To:   *main,
+				Type: artifact.DependencyOfRelationship,
+			})
+		}
+	}
+
+	return pkgs, relationships
+}
+
+func newGoBinaryPackage(m *debug.Module, mainModule, goVersion, arch string, location source.Location) pkg.Package {
+	if m.Replace != nil {
+		m = m.Replace
+	}
+
+	return pkg.Package{
+		Name:         m.Path,
+		Version:      m.Version,
+		FoundBy:      binaryCatalogerName,
+		Locations:    source.NewLocationSet(location),
+		Language:     pkg.Go,
+		Type:         pkg.GoModulePkg,
+		MetadataType: pkg.GolangBinMetadataType,
+		Metadata: pkg.GolangBinMetadata{
+			GoCompiledVersion: goVersion,
+			Architecture:      arch,
+			H1Digest:          m.Sum,
+			MainModule:        mainModule,
+		},
+	}
+}
@@ -1,61 +1,129 @@
 package golang
 
 import (
+	"bufio"
 	"fmt"
 	"io"
-	"sort"
+	"strings"
 
 	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
 
-	"github.com/lovewebshell/minicat/minicat/artifact"
-	"github.com/lovewebshell/minicat/minicat/pkg"
+	"github.com/lovewebshell/minicat/minicat/source"
 )
 
-func parseGoMod(path string, reader io.Reader) ([]*pkg.Package, []artifact.Relationship, error) {
-	packages := make(map[string]*pkg.Package)
+// goModule is a go.mod file along with the go.sum and vendor/modules.txt files next to it.
+type goModule struct {
+	location source.Location
+	file     *modfile.File
+	sums     map[string]string
+	vendor   *goVendor
+}
+
+// goVendor is a vendor/modules.txt file, listing the modules copied into the vendor directory.
+type goVendor struct {
+	location source.Location
+	modules  []vendoredModule
+}
 
+type vendoredModule struct {
+	mod         module.Version
+	replacement *module.Version
+	explicit    bool
+}
+
+func parseGoMod(path string, reader io.Reader) (*modfile.File, error) {
 	contents, err := io.ReadAll(reader)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to read go module: %w", err)
+		return nil, fmt.Errorf("failed to read go module: %w", err)
 	}
 
 	file, err := modfile.Parse(path, contents, nil)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to parse go module: %w", err)
+		return nil, fmt.Errorf("failed to parse go module: %w", err)
 	}
+	return file, nil
+}
 
-	for _, m := range file.Require {
-		packages[m.Mod.Path] = &pkg.Package{
-			Name:     m.Mod.Path,
-			Version:  m.Mod.Version,
-			Language: pkg.Go,
-			Type:     pkg.GoModulePkg,
-		}
+func parseGoWork(path string, reader io.Reader) (*modfile.WorkFile, error) {
+	contents, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read go workspace: %w", err)
 	}
 
-	for _, m := range file.Replace {
-		packages[m.New.Path] = &pkg.Package{
-			Name:     m.New.Path,
-			Version:  m.New.Version,
-			Language: pkg.Go,
-			Type:     pkg.GoModulePkg,
-		}
+	file, err := modfile.ParseWork(path, contents, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse go workspace: %w", err)
 	}
+	return file, nil
+}
 
-	for _, m := range file.Exclude {
-		delete(packages, m.Mod.Path)
+// parseGoSum reads the h1: hashes of a go.sum (or go.work.sum) file keyed by "path@version", leaving out the hashes of
+// go.mod files of modules that are not needed beyond their requirements.
+func parseGoSum(reader io.Reader) (map[string]string, error) {
+	sums := make(map[string]string)
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 3 || strings.HasSuffix(fields[1], "/go.mod") {
+			continue
+		}
+		sums[fields[0]+"@"+fields[1]] = fields[2]
 	}
-
-	pkgsSlice := make([]*pkg.Package, len(packages))
-	idx := 0
-	for _, p := range packages {
-		pkgsSlice[idx] = p
-		idx++
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to parse go.sum file: %w", err)
 	}
+	return sums, nil
+}
 
-	sort.SliceStable(pkgsSlice, func(i, j int) bool {
-		return pkgsSlice[i].Name < pkgsSlice[j].Name
-	})
+// parseVendorModules reads vendor/modules.txt, where every vendored module is introduced by a line such as
+// "# golang.org/x/net v0.1.0 => golang.org/x/net v0.2.0", followed by a "## explicit" line when the module is required
+// by go.mod itself and by the packages used from it.
+func parseVendorModules(reader io.Reader) ([]vendoredModule, error) {
+	var modules []vendoredModule
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "## "):
+			if len(modules) > 0 && strings.HasPrefix(strings.TrimPrefix(line, "## "), "explicit") {
+				modules[len(modules)-1].explicit = true
+			}
+		case strings.HasPrefix(line, "# "):
+			parts := strings.SplitN(strings.TrimPrefix(line, "# "), "=>", 2)
+			fields := strings.Fields(parts[0])
+			if len(fields) == 0 {
+				continue
+			}
+			m := vendoredModule{mod: module.Version{Path: fields[0]}}
+			if len(fields) > 1 {
+				m.mod.Version = fields[1]
+			}
+			if len(parts) == 2 {
+				if replacement := strings.Fields(parts[1]); len(replacement) > 0 {
+					m.replacement = &module.Version{Path: replacement[0]}
+					if len(replacement) > 1 {
+						m.replacement.Version = replacement[1]
+					}
+				}
+			}
+			modules = append(modules, m)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to parse vendor/modules.txt file: %w", err)
+	}
+	return modules, nil
+}
 
-	return pkgsSlice, nil, nil
+func (v *goVendor) contains(path string) bool {
+	if v == nil {
+		return false
+	}
+	for _, m := range v.modules {
+		if m.mod.Path == path {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,115 @@
+package golang
+
+import (
+	"bytes"
+	"debug/buildinfo"
+	"debug/elf"
+	"debug/macho"
+	"fmt"
+	"io"
+
+	macholibre "github.com/anchore/go-macholibre"
+)
+
+type unionReader interface {
+	io.Reader
+	io.ReaderAt
+}
+
+type binaryBuild struct {
+	info *buildinfo.BuildInfo
+	arch string
+}
+
+// scanBinary reads the go build info of every executable in the given file (more than one for Mach-O universal
+// binaries). Files that are not go binaries yield no builds and no error.
+func scanBinary(reader io.Reader) ([]binaryBuild, error) {
+	r, err := getUnionReader(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	readers := []unionReader{r}
+	if macholibre.IsUniversalMachoBinary(r) {
+		extracted, err := macholibre.ExtractReaders(r)
+		if err != nil {
+			return nil, fmt.Errorf("unable to extract universal binary: %w", err)
+		}
+		readers = nil
+		for _, e := range extracted {
+			readers = append(readers, e.Reader)
+		}
+	}
+
+	var builds []binaryBuild
+	for _, br := range readers {
+		info, err := buildinfo.Read(br)
+		if err != nil || info == nil {
+			continue
+		}
+		builds = append(builds, binaryBuild{
+			info: info,
+			arch: getArchitecture(info, br),
+		})
+	}
+	return builds, nil
+}
+
+func getUnionReader(reader io.Reader) (unionReader, error) {
+	if r, ok := reader.(unionReader); ok {
+		return r, nil
+	}
+
+	contents, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read binary: %w", err)
+	}
+	return bytes.NewReader(contents), nil
+}
+
+func getArchitecture(info *buildinfo.BuildInfo, r io.ReaderAt) string {
+	for _, s := range info.Settings {
+		if s.Key == "GOARCH" {
+			return s.Value
+		}
+	}
+
+	if f, err := elf.NewFile(r); err == nil {
+		switch f.Machine {
+		case elf.EM_386:
+			return "386"
+		case elf.EM_X86_64:
+			return "amd64"
+		case elf.EM_ARM:
+			return "arm"
+		case elf.EM_AARCH64:
+			return "arm64"
+		case elf.EM_PPC64:
+			return "ppc64"
+		case elf.EM_S390:
+			return "s390x"
+		case elf.EM_RISCV:
+			return "riscv64"
+		case elf.EM_MIPS:
+			return "mips"
+		}
+		return ""
+	}
+
+	if f, err := macho.NewFile(r); err == nil {
+		switch f.Cpu {
+		case macho.Cpu386:
+			return "386"
+		case macho.CpuAmd64:
+			return "amd64"
+		case macho.CpuArm:
+			return "arm"
+		case macho.CpuArm64:
+			return "arm64"
+		case macho.CpuPpc64:
+			return "ppc64"
+		}
+	}
+
+	return ""
+}
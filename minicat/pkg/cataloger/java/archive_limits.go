@@ -0,0 +1,150 @@
+package java
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/wagoodman/go-partybus"
+
+	"github.com/lovewebshell/minicat/internal/bus"
+	"github.com/lovewebshell/minicat/internal/file"
+	"github.com/lovewebshell/minicat/internal/log"
+	"github.com/lovewebshell/minicat/minicat/event"
+	"github.com/lovewebshell/minicat/minicat/pkg"
+)
+
+// ArchiveLimits bound the archives extracted while scanning a Java archive (or a zip or tar file holding some) and
+// the archives nested in it, so that decompression bombs cannot exhaust the disk or memory. Archives (and files such as
+// manifests and POMs) beyond the limits are left out of the scan, which is published as an event and recorded as a
+// warning on the package of the archive holding them. A zero value disables the corresponding limit.
+type ArchiveLimits struct {
+	// MaxNestingDepth is how deep archives nested within archives are scanned (1 scans the archives of the scanned
+	// archive only, not the archives within them).
+	MaxNestingDepth int
+	// MaxExtractedBytes bounds the size of all the archives extracted while scanning a file, including the copies of
+	// the nested archives made to scan them.
+	MaxExtractedBytes int64
+	// MaxEntryBytes bounds the size of every archive extracted and of every file read.
+	MaxEntryBytes int64
+	// MaxCompressionRatio bounds the ratio between the size of an archive extracted and its compressed size.
+	MaxCompressionRatio int64
+}
+
+func DefaultArchiveLimits() ArchiveLimits {
+	return ArchiveLimits{
+		MaxNestingDepth:     8,
+		MaxExtractedBytes:   4 * file.GB,
+		MaxEntryBytes:       1 * file.GB,
+		MaxCompressionRatio: 100,
+	}
+}
+
+// archiveBudget tracks the bytes extracted while scanning a file, across the archives nested in it.
+type archiveBudget struct {
+	limits    ArchiveLimits
+	extracted int64
+}
+
+func newArchiveBudget(limits ArchiveLimits) *archiveBudget {
+	return &archiveBudget{limits: limits}
+}
+
+// nestingAllowed tells whether the archives nested in an archive at the given depth (0 being the scanned file) are to
+// be scanned.
+func (b *archiveBudget) nestingAllowed(depth int) bool {
+	return b.limits.MaxNestingDepth <= 0 || depth < b.limits.MaxNestingDepth
+}
+
+// errArchiveLimit is returned when an archive (or a copy of one) does not fit in the limits.
+var errArchiveLimit = errors.New("archive limit exceeded")
+
+// check tells whether an entry about to be read is within the limits on the size of every entry and on its compression
+// ratio. The sizes are the ones of the entry headers, which the zip and tar readers hold the entries to.
+func (b *archiveBudget) check(info os.FileInfo) error {
+	size := info.Size()
+	if b.limits.MaxEntryBytes > 0 && size > b.limits.MaxEntryBytes {
+		return fmt.Errorf("%w: size of %d bytes exceeds the limit of %d bytes", errArchiveLimit, size, b.limits.MaxEntryBytes)
+	}
+	if header, ok := info.Sys().(*zip.FileHeader); ok && b.limits.MaxCompressionRatio > 0 {
+		if compressed := int64(header.CompressedSize64); compressed > 0 && size/compressed > b.limits.MaxCompressionRatio {
+			return fmt.Errorf("%w: compression ratio of %d exceeds the limit of %d", errArchiveLimit, size/compressed,
+				b.limits.MaxCompressionRatio)
+		}
+	}
+	return nil
+}
+
+// admit reserves the size of an entry about to be extracted, unless the entry is beyond the limits.
+func (b *archiveBudget) admit(info os.FileInfo) error {
+	if err := b.check(info); err != nil {
+		return err
+	}
+	return b.reserve(info.Size())
+}
+
+// reserve accounts for bytes about to be written, unless they would exceed the limit of bytes extracted.
+func (b *archiveBudget) reserve(size int64) error {
+	if b.limits.MaxExtractedBytes > 0 && b.extracted+size > b.limits.MaxExtractedBytes {
+		return fmt.Errorf("%w: extracting it would exceed the limit of %d bytes extracted", errArchiveLimit,
+			b.limits.MaxExtractedBytes)
+	}
+	b.extracted += size
+	return nil
+}
+
+// admitAll keeps the entries of a zip archive that are within the limits, reserving their size.
+func (b *archiveBudget) admitAll(virtualPath string, manifest file.ZipFileManifest, paths []string, parentPkg *pkg.Package) []string {
+	var admitted []string
+	for _, p := range paths {
+		if info, ok := manifest[p]; ok {
+			if err := b.admit(info); err != nil {
+				warnArchiveLimit(parentPkg, virtualPath, "skipped nested archive %q: %v", p, err)
+				continue
+			}
+		}
+		admitted = append(admitted, p)
+	}
+	return admitted
+}
+
+// reader charges the bytes read from the given reader to the budget, failing once they exceed the limit of bytes
+// extracted (as for the copy of a nested archive made to scan it).
+func (b *archiveBudget) reader(r io.Reader) io.Reader {
+	return &budgetReader{reader: r, budget: b}
+}
+
+type budgetReader struct {
+	reader io.Reader
+	budget *archiveBudget
+}
+
+func (r *budgetReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if reserveErr := r.budget.reserve(int64(n)); reserveErr != nil {
+		return 0, reserveErr
+	}
+	return n, err
+}
+
+// warnArchiveLimit logs and publishes that an archive was not fully scanned, and records it on the package of the
+// archive when there is one (there is none for the zip and tar files holding Java archives).
+func warnArchiveLimit(p *pkg.Package, virtualPath, format string, args ...interface{}) {
+	warning := fmt.Sprintf(format, args...)
+	log.Warnf("java archive (%s): %s", virtualPath, warning)
+	bus.Publish(partybus.Event{
+		Type:   event.JavaArchiveLimitExceeded,
+		Source: virtualPath,
+		Value:  warning,
+	})
+
+	if p == nil {
+		return
+	}
+	if metadata, ok := p.Metadata.(pkg.JavaMetadata); ok {
+		metadata.Warnings = append(metadata.Warnings, warning)
+		p.Metadata = metadata
+	}
+}
@@ -0,0 +1,80 @@
+package java
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wagoodman/go-partybus"
+
+	"github.com/lovewebshell/minicat/internal/bus"
+	"github.com/lovewebshell/minicat/minicat/event"
+	"github.com/lovewebshell/minicat/minicat/pkg"
+)
+
+func TestParseJavaArchive_NestedArchiveCopyIsCharged(t *testing.T) {
+	inner, err := io.ReadAll(newTestJar(t, map[string]string{
+		"META-INF/MANIFEST.MF": "Manifest-Version: 1.0\nImplementation-Title: inner\nImplementation-Version: 1.0\n",
+	}))
+	require.NoError(t, err)
+	outer := newTestJar(t, map[string]string{
+		"META-INF/MANIFEST.MF": "Manifest-Version: 1.0\nImplementation-Title: outer\nImplementation-Version: 1.0\n",
+		"lib/inner-1.0.jar":    string(inner),
+	})
+
+	// extracting the nested archive fits in the limit, but not its copy as well
+	limits := ArchiveLimits{MaxExtractedBytes: int64(len(inner)) * 3 / 2}
+	pkgs, _, err := parseJavaArchive(context.Background(), "outer-1.0.jar", outer, newArchiveBudget(limits), 0)
+	require.NoError(t, err)
+
+	require.Len(t, pkgs, 1)
+	metadata := pkgs[0].Metadata.(pkg.JavaMetadata)
+	require.Len(t, metadata.Warnings, 1)
+	assert.Contains(t, metadata.Warnings[0], `skipped nested archive "lib/inner-1.0.jar"`)
+}
+
+func TestParseJavaArchive_FilesReadAreAdmitted(t *testing.T) {
+	jar := newTestJar(t, map[string]string{
+		"META-INF/MANIFEST.MF": "Manifest-Version: 1.0\nImplementation-Title: app\nImplementation-Version: 1.0\n",
+		// highly compressible, as a decompression bomb would be
+		"META-INF/maven/org.example/app/pom.properties": "groupId=org.example\nartifactId=app\nversion=1.0\n#" +
+			strings.Repeat("a", 100000) + "\n",
+	})
+
+	pkgs, _, err := parseJavaArchive(context.Background(), "app-1.0.jar", jar, newArchiveBudget(DefaultArchiveLimits()), 0)
+	require.NoError(t, err)
+
+	require.Len(t, pkgs, 1)
+	metadata := pkgs[0].Metadata.(pkg.JavaMetadata)
+	assert.Nil(t, metadata.PomProperties)
+	require.Len(t, metadata.Warnings, 1)
+	assert.Contains(t, metadata.Warnings[0], `skipped "META-INF/maven/org.example/app/pom.properties": archive limit exceeded: compression ratio`)
+}
+
+func TestParseZipWrappedJavaArchive_LimitsArePublished(t *testing.T) {
+	b := partybus.NewBus()
+	defer b.Close()
+	subscription := b.Subscribe(event.JavaArchiveLimitExceeded)
+	bus.SetPublisher(b)
+	defer bus.SetPublisher(nil)
+
+	app, err := io.ReadAll(newTestJar(t, map[string]string{
+		"META-INF/MANIFEST.MF": "Manifest-Version: 1.0\n",
+	}))
+	require.NoError(t, err)
+	wrapper := newTestJar(t, map[string]string{
+		"app-1.0.jar": string(app),
+	})
+
+	limits := ArchiveLimits{MaxEntryBytes: 10}
+	pkgs, _, err := parseZipWrappedJavaArchive(context.Background(), "dist.zip", wrapper, newArchiveBudget(limits))
+	require.NoError(t, err)
+	assert.Empty(t, pkgs)
+
+	e := <-subscription.Events()
+	assert.Equal(t, "dist.zip", e.Source)
+	assert.Contains(t, e.Value, `skipped nested archive "app-1.0.jar"`)
+}
@@ -1,11 +1,14 @@
 package java
 
 import (
+	"context"
 	"crypto"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path"
+	"sort"
 	"strings"
 
 	"github.com/lovewebshell/minicat/internal/file"
@@ -16,8 +19,6 @@ import (
 	"github.com/lovewebshell/minicat/minicat/pkg/cataloger/common"
 )
 
-var _ common.ParserFn = parseJavaArchive
-
 var archiveFormatGlobs = []string{
 	"**/*.jar",
 	"**/*.war",
@@ -40,16 +41,25 @@ type archiveParser struct {
 	contentPath  string
 	fileInfo     archiveFilename
 	detectNested bool
+	budget       *archiveBudget
+	depth        int
+	skipped      map[string]bool
+}
+
+func javaArchiveParser(limits ArchiveLimits) common.ParserFn {
+	return func(ctx context.Context, virtualPath string, reader io.Reader) ([]*pkg.Package, []artifact.Relationship, error) {
+		return parseJavaArchive(ctx, virtualPath, reader, newArchiveBudget(limits), 0)
+	}
 }
 
-func parseJavaArchive(virtualPath string, reader io.Reader) ([]*pkg.Package, []artifact.Relationship, error) {
-	parser, cleanupFn, err := newJavaArchiveParser(virtualPath, reader, true)
+func parseJavaArchive(ctx context.Context, virtualPath string, reader io.Reader, budget *archiveBudget, depth int) ([]*pkg.Package, []artifact.Relationship, error) {
+	parser, cleanupFn, err := newJavaArchiveParser(virtualPath, reader, true, budget, depth)
 
 	defer cleanupFn()
 	if err != nil {
 		return nil, nil, err
 	}
-	return parser.parse()
+	return parser.parse(ctx)
 }
 
 func uniquePkgKey(p *pkg.Package) string {
@@ -59,11 +69,15 @@ func uniquePkgKey(p *pkg.Package) string {
 	return fmt.Sprintf("%s|%s", p.Name, p.Version)
 }
 
-func newJavaArchiveParser(virtualPath string, reader io.Reader, detectNested bool) (*archiveParser, func(), error) {
+func newJavaArchiveParser(virtualPath string, reader io.Reader, detectNested bool, budget *archiveBudget, depth int) (*archiveParser, func(), error) {
 
 	virtualElements := strings.Split(virtualPath, ":")
 	currentFilepath := virtualElements[len(virtualElements)-1]
 
+	if depth > 0 {
+		// the copy of a nested archive adds to the bytes extracted for it
+		reader = budget.reader(reader)
+	}
 	contentPath, archivePath, cleanupFn, err := saveArchiveToTmp(currentFilepath, reader)
 	if err != nil {
 		return nil, cleanupFn, fmt.Errorf("unable to process java archive: %w", err)
@@ -81,10 +95,13 @@ func newJavaArchiveParser(virtualPath string, reader io.Reader, detectNested boo
 		contentPath:  contentPath,
 		fileInfo:     newJavaArchiveFilename(currentFilepath),
 		detectNested: detectNested,
+		budget:       budget,
+		depth:        depth,
+		skipped:      make(map[string]bool),
 	}, cleanupFn, nil
 }
 
-func (j *archiveParser) parse() ([]*pkg.Package, []artifact.Relationship, error) {
+func (j *archiveParser) parse(ctx context.Context) ([]*pkg.Package, []artifact.Relationship, error) {
 	var pkgs []*pkg.Package
 	var relationships []artifact.Relationship
 
@@ -93,19 +110,12 @@ func (j *archiveParser) parse() ([]*pkg.Package, []artifact.Relationship, error)
 	if err != nil {
 		return nil, nil, fmt.Errorf("could not generate package from %s: %w", j.virtualPath, err)
 	}
-	//先不从maven文件里获取组件，因为存在META-INF\maven文件下虽然包含某个组件，但是实际上却不包含的情况
-	//如：nacos-client的META-INF\maven\io.netty包含netty-handler，但是用mvn dependency:tree查看依赖树却没有netty-handler
-	//auxPkgs, err := j.discoverPkgsFromAllMavenFiles(parentPkg)
-	//if err != nil {
-	//	return nil, nil, err
-	//}
-	//if len(auxPkgs) > 0 {
-	//	fmt.Println("xxx")
-	//}
-	//pkgs = append(pkgs, auxPkgs...)
+	// the Maven metadata under META-INF/maven is not enough to report the artifacts it describes, as it may remain from
+	// artifacts that are not bundled (nacos-client holds the metadata of io.netty:netty-handler without its classes):
+	// discoverShadedPkgs only reports the ones whose classes are present.
 
 	if parentPkg != nil {
-		properties, err := pomPropertiesByParentPath(j.archivePath, j.virtualPath, j.fileManifest.GlobMatch(pomPropertiesGlob))
+		properties, err := pomPropertiesByParentPath(j.archivePath, j.virtualPath, j.readablePaths(parentPkg, pomPropertiesGlob))
 		if err != nil {
 			return nil, nil, err
 		}
@@ -122,9 +132,16 @@ func (j *archiveParser) parse() ([]*pkg.Package, []artifact.Relationship, error)
 		}
 	}
 
+	shadedPkgs, shadedRelationships, err := j.discoverShadedPkgs(parentPkg)
+	if err != nil {
+		return nil, nil, err
+	}
+	pkgs = append(pkgs, shadedPkgs...)
+	relationships = append(relationships, shadedRelationships...)
+
 	if j.detectNested {
 
-		nestedPkgs, nestedRelationships, err := j.discoverPkgsFromNestedArchives(parentPkg)
+		nestedPkgs, nestedRelationships, err := j.discoverPkgsFromNestedArchives(ctx, parentPkg)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -148,7 +165,9 @@ func (j *archiveParser) discoverMainPackage() (*pkg.Package, error) {
 	manifestMatches := j.fileManifest.GlobMatch(manifestGlob)
 	if len(manifestMatches) > 1 {
 		return nil, fmt.Errorf("found multiple manifests in the jar: %+v", manifestMatches)
-	} else if len(manifestMatches) == 0 {
+	}
+	manifestMatches = j.readablePaths(nil, manifestGlob)
+	if len(manifestMatches) == 0 {
 
 		return nil, nil
 	}
@@ -197,12 +216,12 @@ func (j *archiveParser) discoverPkgsFromAllMavenFiles(parentPkg *pkg.Package) ([
 
 	var pkgs []*pkg.Package
 
-	properties, err := pomPropertiesByParentPath(j.archivePath, j.virtualPath, j.fileManifest.GlobMatch(pomPropertiesGlob))
+	properties, err := pomPropertiesByParentPath(j.archivePath, j.virtualPath, j.readablePaths(parentPkg, pomPropertiesGlob))
 	if err != nil {
 		return nil, err
 	}
 
-	projects, err := pomProjectByParentPath(j.archivePath, j.virtualPath, j.fileManifest.GlobMatch(pomXMLGlob))
+	projects, err := pomProjectByParentPath(j.archivePath, j.virtualPath, j.readablePaths(parentPkg, pomXMLGlob))
 	if err != nil {
 		return nil, err
 	}
@@ -222,27 +241,67 @@ func (j *archiveParser) discoverPkgsFromAllMavenFiles(parentPkg *pkg.Package) ([
 	return pkgs, nil
 }
 
-func (j *archiveParser) discoverPkgsFromNestedArchives(parentPkg *pkg.Package) ([]*pkg.Package, []artifact.Relationship, error) {
+// readablePaths are the files of the archive matching the given globs that are within the limits on every entry, as
+// the files read into memory (such as manifests and POMs) are held to the same limits as the archives extracted.
+func (j *archiveParser) readablePaths(parentPkg *pkg.Package, globs ...string) []string {
+	var paths []string
+	for _, p := range j.fileManifest.GlobMatch(globs...) {
+		if err := j.budget.check(j.fileManifest[p]); err != nil {
+			if !j.skipped[p] {
+				j.skipped[p] = true
+				warnArchiveLimit(parentPkg, j.virtualPath, "skipped %q: %v", p, err)
+			}
+			continue
+		}
+		paths = append(paths, p)
+	}
+	return paths
+}
+
+func (j *archiveParser) discoverPkgsFromNestedArchives(ctx context.Context, parentPkg *pkg.Package) ([]*pkg.Package, []artifact.Relationship, error) {
+	if !j.budget.nestingAllowed(j.depth) {
+		if nested := j.fileManifest.GlobMatch(archiveFormatGlobs...); len(nested) > 0 {
+			warnArchiveLimit(parentPkg, j.virtualPath, "skipped %d nested archives: nesting depth exceeds the limit of %d",
+				len(nested), j.budget.limits.MaxNestingDepth)
+		}
+		return nil, nil, nil
+	}
 
-	return discoverPkgsFromZip(j.virtualPath, j.archivePath, j.contentPath, j.fileManifest, parentPkg)
+	return discoverPkgsFromZip(ctx, j.virtualPath, j.archivePath, j.contentPath, j.fileManifest, parentPkg, j.budget, j.depth)
 }
 
-func discoverPkgsFromZip(virtualPath, archivePath, contentPath string, fileManifest file.ZipFileManifest, parentPkg *pkg.Package) ([]*pkg.Package, []artifact.Relationship, error) {
+func discoverPkgsFromZip(ctx context.Context, virtualPath, archivePath, contentPath string, fileManifest file.ZipFileManifest, parentPkg *pkg.Package, budget *archiveBudget, depth int) ([]*pkg.Package, []artifact.Relationship, error) {
+	paths := budget.admitAll(virtualPath, fileManifest, fileManifest.GlobMatch(archiveFormatGlobs...), parentPkg)
 
-	openers, err := file.ExtractFromZipToUniqueTempFile(archivePath, contentPath, fileManifest.GlobMatch(archiveFormatGlobs...)...)
+	openers, err := file.ExtractFromZipToUniqueTempFile(ctx, archivePath, contentPath, paths...)
 	if err != nil {
 		return nil, nil, fmt.Errorf("unable to extract files from zip: %w", err)
 	}
 
-	return discoverPkgsFromOpeners(virtualPath, openers, parentPkg)
+	return discoverPkgsFromOpeners(ctx, virtualPath, openers, parentPkg, budget, depth+1)
 }
 
-func discoverPkgsFromOpeners(virtualPath string, openers map[string]file.Opener, parentPkg *pkg.Package) ([]*pkg.Package, []artifact.Relationship, error) {
+func discoverPkgsFromOpeners(ctx context.Context, virtualPath string, openers map[string]file.Opener, parentPkg *pkg.Package, budget *archiveBudget, depth int) ([]*pkg.Package, []artifact.Relationship, error) {
 	var pkgs []*pkg.Package
 	var relationships []artifact.Relationship
 
-	for pathWithinArchive, archiveOpener := range openers {
-		nestedPkgs, nestedRelationships, err := discoverPkgsFromOpener(virtualPath, pathWithinArchive, archiveOpener)
+	// the nested archives share the budget of the scanned file, which is spent in a stable order
+	paths := make([]string, 0, len(openers))
+	for pathWithinArchive := range openers {
+		paths = append(paths, pathWithinArchive)
+	}
+	sort.Strings(paths)
+
+	for _, pathWithinArchive := range paths {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+
+		nestedPkgs, nestedRelationships, err := discoverPkgsFromOpener(ctx, virtualPath, pathWithinArchive, openers[pathWithinArchive], budget, depth)
+		if errors.Is(err, errArchiveLimit) {
+			warnArchiveLimit(parentPkg, virtualPath, "skipped nested archive %q: %v", pathWithinArchive, err)
+			continue
+		}
 		if err != nil {
 			log.Warnf("unable to discover java packages from opener (%s): %+v", virtualPath, err)
 			continue
@@ -250,8 +309,13 @@ func discoverPkgsFromOpeners(virtualPath string, openers map[string]file.Opener,
 
 		for _, p := range nestedPkgs {
 			if metadata, ok := p.Metadata.(pkg.JavaMetadata); ok {
-				if metadata.Parent == nil {
+				if metadata.Parent == nil && parentPkg != nil {
 					metadata.Parent = parentPkg
+					relationships = append(relationships, artifact.Relationship{
+						From: parentPkg,
+						To:   p,
+						Type: artifact.ContainsRelationship,
+					})
 				}
 				p.Metadata = metadata
 			}
@@ -264,7 +328,7 @@ func discoverPkgsFromOpeners(virtualPath string, openers map[string]file.Opener,
 	return pkgs, relationships, nil
 }
 
-func discoverPkgsFromOpener(virtualPath, pathWithinArchive string, archiveOpener file.Opener) ([]*pkg.Package, []artifact.Relationship, error) {
+func discoverPkgsFromOpener(ctx context.Context, virtualPath, pathWithinArchive string, archiveOpener file.Opener, budget *archiveBudget, depth int) ([]*pkg.Package, []artifact.Relationship, error) {
 	archiveReadCloser, err := archiveOpener.Open()
 	if err != nil {
 		return nil, nil, fmt.Errorf("unable to open archived file from tempdir: %w", err)
@@ -276,7 +340,7 @@ func discoverPkgsFromOpener(virtualPath, pathWithinArchive string, archiveOpener
 	}()
 
 	nestedPath := fmt.Sprintf("%s:%s", virtualPath, pathWithinArchive)
-	nestedPkgs, nestedRelationships, err := parseJavaArchive(nestedPath, archiveReadCloser)
+	nestedPkgs, nestedRelationships, err := parseJavaArchive(ctx, nestedPath, archiveReadCloser, budget, depth)
 	if err != nil {
 		return nil, nil, fmt.Errorf("unable to process nested java archive (%s): %w", pathWithinArchive, err)
 	}
@@ -0,0 +1,27 @@
+package java
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseJavaArchive_Cancelled(t *testing.T) {
+	inner, err := io.ReadAll(newTestJar(t, map[string]string{
+		"META-INF/MANIFEST.MF": "Manifest-Version: 1.0\nImplementation-Title: inner\nImplementation-Version: 1.0\n",
+	}))
+	require.NoError(t, err)
+	outer := newTestJar(t, map[string]string{
+		"META-INF/MANIFEST.MF": "Manifest-Version: 1.0\nImplementation-Title: outer\nImplementation-Version: 1.0\n",
+		"lib/inner-1.0.jar":    string(inner),
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, err = parseJavaArchive(ctx, "outer-1.0.jar", outer, newArchiveBudget(DefaultArchiveLimits()), 0)
+	assert.ErrorIs(t, err, context.Canceled)
+}
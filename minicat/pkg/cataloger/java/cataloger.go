@@ -1,5 +1,6 @@
 /*
-Package java provides a concrete Cataloger implementation for Java archives (jar, war, ear, par, sar, jpi, hpi formats).
+Package java provides a concrete Cataloger implementation for Java archives (jar, war, ear, par, sar, jpi, hpi formats),
+Maven POM files, Gradle lockfiles and version catalogs, and JDK and JRE installations.
 */
 package java
 
@@ -11,22 +12,33 @@ func NewJavaCataloger(cfg Config) *common.GenericCataloger {
 	globParsers := make(map[string]common.ParserFn)
 
 	for _, pattern := range archiveFormatGlobs {
-		globParsers[pattern] = parseJavaArchive
+		globParsers[pattern] = javaArchiveParser(cfg.ArchiveLimits)
 	}
 
 	if cfg.SearchIndexedArchives {
 
 		for _, pattern := range genericZipGlobs {
-			globParsers[pattern] = parseZipWrappedJavaArchive
+			globParsers[pattern] = zipWrappedJavaArchiveParser(cfg.ArchiveLimits)
 		}
 	}
 
 	if cfg.SearchUnindexedArchives {
 
 		for _, pattern := range genericTarGlobs {
-			globParsers[pattern] = parseTarWrappedJavaArchive
+			globParsers[pattern] = tarWrappedJavaArchiveParser(cfg.ArchiveLimits)
 		}
 	}
 
 	return common.NewGenericCataloger(nil, globParsers, "java-cataloger")
 }
+
+// NewJavaGradleCataloger returns a cataloger for the modules locked by Gradle lockfiles and declared by version catalogs.
+func NewJavaGradleCataloger() *common.GenericCataloger {
+	globParsers := map[string]common.ParserFn{
+		"**/gradle.lockfile":             parseGradleLockfile,
+		"**/buildscript-gradle.lockfile": parseGradleLockfile,
+		"**/gradle/libs.versions.toml":   parseGradleVersionCatalog,
+	}
+
+	return common.NewGenericCataloger(nil, globParsers, javaGradleCataloger)
+}
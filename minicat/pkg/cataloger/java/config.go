@@ -3,4 +3,6 @@ package java
 type Config struct {
 	SearchUnindexedArchives bool
 	SearchIndexedArchives   bool
+	MavenLocalRepository    string
+	ArchiveLimits           ArchiveLimits
 }
@@ -0,0 +1,171 @@
+package java
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/lovewebshell/minicat/internal"
+	"github.com/lovewebshell/minicat/internal/log"
+	"github.com/lovewebshell/minicat/minicat/artifact"
+	"github.com/lovewebshell/minicat/minicat/pkg"
+	"github.com/lovewebshell/minicat/minicat/source"
+)
+
+const (
+	javaJVMCataloger = "java-jvm-cataloger"
+	jvmReleaseGlob   = "**/release"
+)
+
+// jvmRuntimePaths are the files (relative to the java home) of which one is part of any JDK or JRE: the launcher and
+// the runtime classes (lib/modules since Java 9, lib/rt.jar before, possibly within a jre directory).
+var jvmRuntimePaths = []string{
+	"bin/java",
+	"lib/modules",
+	"jre/bin/java",
+	"lib/rt.jar",
+	"jre/lib/rt.jar",
+}
+
+type JVMCataloger struct{}
+
+func NewJavaJVMCataloger() *JVMCataloger {
+	return &JVMCataloger{}
+}
+
+func (c *JVMCataloger) Name() string {
+	return javaJVMCataloger
+}
+
+// Catalog finds the JDK and JRE installations, that is the release files next to a Java runtime.
+func (c *JVMCataloger) Catalog(ctx context.Context, resolver source.FileResolver) ([]pkg.Package, []artifact.Relationship, error) {
+	locations, err := resolver.FilesByGlob(ctx, jvmReleaseGlob)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find files by glob: %s", jvmReleaseGlob)
+	}
+
+	var pkgs []pkg.Package
+	for _, location := range locations {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+
+		javaHome := path.Dir(location.RealPath)
+		if !jvmFilesExist(resolver, javaHome, jvmRuntimePaths...) {
+			continue
+		}
+
+		reader, err := resolver.FileContentsByLocation(location)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to fetch contents at location=%v: %w", location, err)
+		}
+		release, err := parseJVMRelease(reader)
+		internal.CloseAndLogError(reader, location.VirtualPath)
+		if err != nil {
+			log.Warnf("cataloger '%s' failed to parse entries at location=%+v: %+v", javaJVMCataloger, location, err)
+			continue
+		}
+		if release["JAVA_VERSION"] == "" {
+			continue
+		}
+
+		imageType := "jre"
+		if jvmFilesExist(resolver, javaHome, "bin/javac") {
+			imageType = "jdk"
+		}
+
+		p := newJVMPackage(release, javaHome, imageType)
+		p.FoundBy = javaJVMCataloger
+		p.Locations.Add(location)
+		p.SetID()
+		pkgs = append(pkgs, *p)
+	}
+
+	return pkgs, nil, nil
+}
+
+func jvmFilesExist(resolver source.FileResolver, javaHome string, paths ...string) bool {
+	for _, p := range paths {
+		locations, err := resolver.FilesByPath(path.Join(javaHome, p))
+		if err == nil && len(locations) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// parseJVMRelease reads the KEY="value" pairs of the release file of a java home.
+func parseJVMRelease(reader io.Reader) (map[string]string, error) {
+	release := make(map[string]string)
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(strings.TrimSpace(scanner.Text()), "=")
+		if !ok || strings.HasPrefix(key, "#") {
+			continue
+		}
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			value = unquoted
+		} else {
+			value = strings.Trim(value, `"'`)
+		}
+		release[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to parse release file: %w", err)
+	}
+	return release, nil
+}
+
+func newJVMPackage(release map[string]string, javaHome, imageType string) *pkg.Package {
+	vendor, product := jvmDistribution(release, imageType)
+	metadata := pkg.JavaVMInstallationMetadata{
+		Vendor:             vendor,
+		Product:            product,
+		ImageType:          imageType,
+		JavaHome:           javaHome,
+		JavaVersion:        release["JAVA_VERSION"],
+		JavaRuntimeVersion: release["JAVA_RUNTIME_VERSION"],
+		FullVersion:        release["FULL_VERSION"],
+		Implementor:        release["IMPLEMENTOR"],
+		ImplementorVersion: release["IMPLEMENTOR_VERSION"],
+		GraalVMVersion:     release["GRAALVM_VERSION"],
+	}
+
+	return &pkg.Package{
+		Name:         product,
+		Version:      metadata.BuildVersion(),
+		Language:     pkg.Java,
+		Type:         pkg.BinaryPkg,
+		MetadataType: pkg.JavaVMInstallationMetadataType,
+		Metadata:     metadata,
+	}
+}
+
+// jvmDistribution tells the vendor and product of a JVM from the implementor recorded in its release file. Builds of
+// OpenJDK that do not identify a known distribution are reported as OpenJDK, while the commercial builds by Oracle are
+// reported as the Oracle JDK or JRE.
+func jvmDistribution(release map[string]string, imageType string) (string, string) {
+	implementor := strings.ToLower(release["IMPLEMENTOR"])
+	implementorVersion := strings.ToLower(release["IMPLEMENTOR_VERSION"])
+
+	switch {
+	case release["GRAALVM_VERSION"] != "" || strings.Contains(implementor, "graalvm") ||
+		strings.Contains(implementorVersion, "graalvm"):
+		return "oracle", "graalvm"
+	case strings.Contains(implementor, "adoptium") || strings.HasPrefix(implementorVersion, "temurin"):
+		return "eclipse", "temurin"
+	case strings.Contains(implementor, "azul") || strings.HasPrefix(implementorVersion, "zulu"):
+		return "azul", "zulu"
+	case strings.Contains(implementor, "amazon") || strings.HasPrefix(implementorVersion, "corretto"):
+		return "amazon", "corretto"
+	case strings.EqualFold(release["BUILD_TYPE"], "commercial") && (implementor == "" || strings.Contains(implementor, "oracle")):
+		// the release files of the Oracle JDK and JRE 8 name no implementor
+		return "oracle", imageType
+	default:
+		return "oracle", "openjdk"
+	}
+}
@@ -0,0 +1,74 @@
+package java
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lovewebshell/minicat/minicat/pkg"
+	"github.com/lovewebshell/minicat/minicat/pkg/cataloger/common/cpe"
+	"github.com/lovewebshell/minicat/minicat/source"
+)
+
+func TestJVMCataloger(t *testing.T) {
+	src, err := source.NewFromDirectory("test-fixtures/jvm")
+	require.NoError(t, err)
+	resolver, err := src.FileResolver(source.SquashedScope)
+	require.NoError(t, err)
+
+	pkgs, _, err := NewJavaJVMCataloger().Catalog(context.Background(), resolver)
+	require.NoError(t, err)
+
+	byHome := make(map[string]pkg.Package)
+	for _, p := range pkgs {
+		byHome[p.Metadata.(pkg.JavaVMInstallationMetadata).JavaHome] = p
+	}
+	require.Len(t, byHome, 2, "the release file without a runtime next to it is not an installation")
+
+	tests := []struct {
+		javaHome string
+		name     string
+		version  string
+		purl     string
+		cpes     []string
+	}{
+		{
+			javaHome: "oracle-jdk-8",
+			name:     "jdk",
+			version:  "1.8.0_371",
+			purl:     "pkg:generic/oracle/jdk@1.8.0_371",
+			cpes: []string{
+				"cpe:2.3:a:oracle:java_se:1.8.0:update_371:*:*:*:*:*:*",
+				"cpe:2.3:a:oracle:jdk:1.8.0:update_371:*:*:*:*:*:*",
+			},
+		},
+		{
+			javaHome: "temurin-17",
+			name:     "temurin",
+			version:  "17.0.8+7",
+			purl:     "pkg:generic/eclipse/temurin@17.0.8+7",
+			cpes: []string{
+				"cpe:2.3:a:eclipse:temurin:17.0.8:*:*:*:*:*:*:*",
+				"cpe:2.3:a:oracle:openjdk:17.0.8:*:*:*:*:*:*:*",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.javaHome, func(t *testing.T) {
+			p, ok := byHome[test.javaHome]
+			require.True(t, ok)
+
+			assert.Equal(t, test.name, p.Name)
+			assert.Equal(t, test.version, p.Version)
+			assert.Equal(t, test.purl, pkg.URL(p, nil))
+
+			var cpes []string
+			for _, c := range cpe.Generate(p) {
+				cpes = append(cpes, pkg.CPEString(c))
+			}
+			assert.ElementsMatch(t, test.cpes, cpes)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package java
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/lovewebshell/minicat/minicat/artifact"
+	"github.com/lovewebshell/minicat/minicat/pkg"
+	"github.com/lovewebshell/minicat/minicat/pkg/cataloger/common"
+)
+
+const javaGradleCataloger = "java-gradle-cataloger"
+
+var _ common.ParserFn = parseGradleLockfile
+
+// parseGradleLockfile reads a gradle.lockfile (or buildscript-gradle.lockfile), where every line locks a module for a
+// set of configurations:
+//
+//	com.google.guava:guava:32.1.2-jre=compileClasspath,runtimeClasspath
+//
+// The "empty=..." line lists the configurations that resolved no module.
+func parseGradleLockfile(_ context.Context, _ string, reader io.Reader) ([]*pkg.Package, []artifact.Relationship, error) {
+	var pkgs []*pkg.Package
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		coordinates, configurations, _ := strings.Cut(line, "=")
+		fields := strings.Split(coordinates, ":")
+		if len(fields) != 3 || fields[0] == "" || fields[1] == "" {
+			continue
+		}
+
+		pkgs = append(pkgs, newGradlePackage(fields[0], fields[1], fields[2], splitGradleConfigurations(configurations)))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse gradle lockfile: %w", err)
+	}
+
+	return pkgs, nil, nil
+}
+
+func splitGradleConfigurations(value string) []string {
+	var configurations []string
+	for _, c := range strings.Split(value, ",") {
+		if c = strings.TrimSpace(c); c != "" {
+			configurations = append(configurations, c)
+		}
+	}
+	sort.Strings(configurations)
+	return configurations
+}
+
+func newGradlePackage(groupID, artifactID, version string, configurations []string) *pkg.Package {
+	return &pkg.Package{
+		Name:         artifactID,
+		Version:      version,
+		Language:     pkg.Gradle,
+		Type:         pkg.JavaPkg,
+		MetadataType: pkg.GradleMetadataType,
+		Metadata: pkg.GradleMetadata{
+			GroupID:        groupID,
+			ArtifactID:     artifactID,
+			Version:        version,
+			Configurations: configurations,
+		},
+	}
+}
@@ -0,0 +1,94 @@
+package java
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/pelletier/go-toml"
+
+	"github.com/lovewebshell/minicat/minicat/artifact"
+	"github.com/lovewebshell/minicat/minicat/pkg"
+	"github.com/lovewebshell/minicat/minicat/pkg/cataloger/common"
+)
+
+var _ common.ParserFn = parseGradleVersionCatalog
+
+// parseGradleVersionCatalog reads the libraries of a version catalog (gradle/libs.versions.toml). A library is either
+// declared in the "group:artifact:version" notation or as a table naming the module (or its group and name) and its
+// version, which may refer to an entry of the [versions] table. Libraries without a version are left to platforms or
+// constraints and are reported without one.
+func parseGradleVersionCatalog(_ context.Context, _ string, reader io.Reader) ([]*pkg.Package, []artifact.Relationship, error) {
+	tree, err := toml.LoadReader(reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to load version catalog for parsing: %v", err)
+	}
+
+	versions := make(map[string]string)
+	if table, ok := tree.Get("versions").(*toml.Tree); ok {
+		for _, key := range table.Keys() {
+			versions[key] = gradleCatalogVersion(table.Get(key))
+		}
+	}
+
+	libraries, ok := tree.Get("libraries").(*toml.Tree)
+	if !ok {
+		return nil, nil, nil
+	}
+
+	keys := libraries.Keys()
+	sort.Strings(keys)
+
+	var pkgs []*pkg.Package
+	for _, key := range keys {
+		var groupID, artifactID, version string
+		switch library := libraries.Get(key).(type) {
+		case string:
+			fields := strings.Split(library, ":")
+			if len(fields) < 2 {
+				continue
+			}
+			groupID, artifactID = fields[0], fields[1]
+			if len(fields) > 2 {
+				version = fields[2]
+			}
+		case *toml.Tree:
+			if module, ok := library.Get("module").(string); ok {
+				groupID, artifactID, _ = strings.Cut(module, ":")
+			} else {
+				groupID, _ = library.Get("group").(string)
+				artifactID, _ = library.Get("name").(string)
+			}
+			if ref, ok := library.GetPath([]string{"version", "ref"}).(string); ok {
+				version = versions[ref]
+			} else {
+				version = gradleCatalogVersion(library.Get("version"))
+			}
+		}
+		if groupID == "" || artifactID == "" {
+			continue
+		}
+
+		pkgs = append(pkgs, newGradlePackage(groupID, artifactID, version, nil))
+	}
+
+	return pkgs, nil, nil
+}
+
+// gradleCatalogVersion is the version of a catalog entry, which is either a plain version or a rich version, of which
+// the strict version takes precedence over the required and the preferred ones.
+func gradleCatalogVersion(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case *toml.Tree:
+		for _, key := range []string{"strictly", "require", "prefer"} {
+			if version, ok := v.Get(key).(string); ok && version != "" {
+				return version
+			}
+		}
+	}
+	return ""
+}
@@ -9,32 +9,12 @@ import (
 	"github.com/vifraa/gopom"
 	"golang.org/x/net/html/charset"
 
-	"github.com/lovewebshell/minicat/minicat/artifact"
 	"github.com/lovewebshell/minicat/minicat/pkg"
 )
 
 const pomXMLGlob = "*pom.xml"
 const pomXMLDirGlob = "**/pom.xml"
 
-func parserPomXML(path string, content io.Reader) ([]*pkg.Package, []artifact.Relationship, error) {
-	pom, err := decodePomXML(content)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	var pkgs []*pkg.Package
-	for _, dep := range pom.Dependencies {
-		p := newPackageFromPom(dep)
-		if p.Name == "" {
-			continue
-		}
-
-		pkgs = append(pkgs, p)
-	}
-
-	return pkgs, nil, nil
-}
-
 func parsePomXMLProject(path string, reader io.Reader) (*pkg.PomProject, error) {
 	project, err := decodePomXML(reader)
 	if err != nil {
@@ -66,12 +46,18 @@ func newPackageFromPom(dep gopom.Dependency) *pkg.Package {
 		FoundBy:      javaPomCataloger,
 		Metadata: pkg.JavaMetadata{
 			PomProperties: &pkg.PomProperties{
-				GroupID: dep.GroupID,
+				GroupID:    dep.GroupID,
+				ArtifactID: dep.ArtifactID,
+				Version:    dep.Version,
+				Scope:      dep.Scope,
 			},
 		},
 	}
 
-	p.Metadata = pkg.JavaMetadata{PURL: packageURL(*p)}
+	if m, ok := p.Metadata.(pkg.JavaMetadata); ok {
+		m.PURL = packageURL(*p)
+		p.Metadata = m
+	}
 
 	return p
 }
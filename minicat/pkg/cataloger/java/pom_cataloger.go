@@ -1,13 +1,82 @@
 package java
 
-import "github.com/lovewebshell/minicat/minicat/pkg/cataloger/common"
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/lovewebshell/minicat/internal"
+	"github.com/lovewebshell/minicat/internal/log"
+	"github.com/lovewebshell/minicat/minicat/artifact"
+	"github.com/lovewebshell/minicat/minicat/pkg"
+	"github.com/lovewebshell/minicat/minicat/source"
+)
 
 const javaPomCataloger = "java-pom-cataloger"
 
-func NewJavaPomCataloger() *common.GenericCataloger {
-	globParsers := make(map[string]common.ParserFn)
+type PomCataloger struct {
+	cfg Config
+}
+
+func NewJavaPomCataloger(cfg Config) *PomCataloger {
+	return &PomCataloger{cfg: cfg}
+}
+
+func (c *PomCataloger) Name() string {
+	return javaPomCataloger
+}
+
+// Catalog expresses the dependencies declared by every pom.xml file as packages. Versions and scopes left to the
+// parents of a POM or the BOMs it imports are resolved from the POMs found among the scanned files, or else from the
+// configured local repository.
+func (c *PomCataloger) Catalog(ctx context.Context, resolver source.FileResolver) ([]pkg.Package, []artifact.Relationship, error) {
+	locations, err := resolver.FilesByGlob(ctx, pomXMLDirGlob)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find files by glob: %s", pomXMLDirGlob)
+	}
+	sort.Slice(locations, func(i, j int) bool {
+		return locations[i].RealPath < locations[j].RealPath
+	})
+
+	poms := newPomResolver(c.cfg.MavenLocalRepository)
+	var found []source.Location
+	for _, location := range locations {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+		reader, err := resolver.FileContentsByLocation(location)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to fetch contents at location=%v: %w", location, err)
+		}
+		project, err := decodePomXML(reader)
+		internal.CloseAndLogError(reader, location.VirtualPath)
+		if err != nil {
+			log.Warnf("cataloger '%s' failed to parse entries at location=%+v: %+v", javaPomCataloger, location, err)
+			continue
+		}
+		poms.add(location.RealPath, project)
+		found = append(found, location)
+	}
 
-	globParsers[pomXMLDirGlob] = parserPomXML
+	var pkgs []pkg.Package
+	for _, location := range found {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+		deps, err := poms.dependencies(ctx, location.RealPath)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, dep := range deps {
+			p := newPackageFromPom(dep)
+			if p.Name == "" {
+				continue
+			}
+			p.Locations.Add(location)
+			p.SetID()
+			pkgs = append(pkgs, *p)
+		}
+	}
 
-	return common.NewGenericCataloger(nil, globParsers, javaPomCataloger)
+	return pkgs, nil, nil
 }
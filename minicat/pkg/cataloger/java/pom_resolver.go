@@ -0,0 +1,289 @@
+package java
+
+import (
+	"context"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/vifraa/gopom"
+
+	"github.com/lovewebshell/minicat/internal/log"
+)
+
+// maxPomDepth bounds the rounds of interpolation of a value, which guards against properties referencing each other.
+const maxPomDepth = 16
+
+var pomPropertyExp = regexp.MustCompile(`\$\{([^}]+)}`)
+
+// pomResolver builds the effective model of the scanned POMs, as far as their dependencies go: the properties and the
+// dependency management inherited from their parents and imported from BOMs. The effective model of every POM is
+// resolved once; a POM reached again while its own model is being resolved (through a cycle of parents or imports)
+// contributes an empty model.
+type pomResolver struct {
+	localRepository string
+	byPath          map[string]*gopom.Project
+	byCoordinates   map[string]string
+	effectives      map[string]effectivePom
+	resolving       map[string]bool
+}
+
+// effectivePom holds the properties and managed dependencies (keyed by their interpolated "groupId:artifactId") of a
+// POM.
+type effectivePom struct {
+	properties map[string]string
+	managed    map[string]gopom.Dependency
+}
+
+func newPomResolver(localRepository string) *pomResolver {
+	return &pomResolver{
+		localRepository: localRepository,
+		byPath:          make(map[string]*gopom.Project),
+		byCoordinates:   make(map[string]string),
+		effectives:      make(map[string]effectivePom),
+		resolving:       make(map[string]bool),
+	}
+}
+
+func (r *pomResolver) add(p string, project gopom.Project) {
+	r.byPath[p] = &project
+	groupID, artifactID, version := pomCoordinates(project)
+	key := groupID + ":" + artifactID + ":" + version
+	if _, ok := r.byCoordinates[key]; !ok {
+		r.byCoordinates[key] = p
+	}
+}
+
+// dependencies are the dependencies declared by the POM at the given path, with properties interpolated, versions and
+// scopes taken from the dependency management when they are not declared, and the scope defaulting to compile.
+func (r *pomResolver) dependencies(ctx context.Context, p string) ([]gopom.Dependency, error) {
+	project, ok := r.byPath[p]
+	if !ok {
+		return nil, nil
+	}
+	effective, err := r.effective(ctx, p, project)
+	if err != nil {
+		return nil, err
+	}
+
+	var deps []gopom.Dependency
+	for _, dep := range project.Dependencies {
+		dep = effective.interpolateDependency(dep)
+		if managed, ok := effective.managed[dep.GroupID+":"+dep.ArtifactID]; ok {
+			managed = effective.interpolateDependency(managed)
+			if dep.Version == "" {
+				dep.Version = managed.Version
+			}
+			if dep.Scope == "" {
+				dep.Scope = managed.Scope
+			}
+		}
+		if dep.Scope == "" {
+			dep.Scope = "compile"
+		}
+		deps = append(deps, dep)
+	}
+	return deps, nil
+}
+
+func (r *pomResolver) effective(ctx context.Context, p string, project *gopom.Project) (effectivePom, error) {
+	if cached, ok := r.effectives[p]; ok {
+		return cached, nil
+	}
+	effective := effectivePom{
+		properties: make(map[string]string),
+		managed:    make(map[string]gopom.Dependency),
+	}
+	if err := ctx.Err(); err != nil {
+		return effective, err
+	}
+	if r.resolving[p] {
+		log.Debugf("stopped resolving POM=%q: its parents or imports lead back to it", p)
+		return effective, nil
+	}
+	r.resolving[p] = true
+	defer delete(r.resolving, p)
+
+	// the managed dependencies inherited and declared are keyed once the properties of this POM are known, since their
+	// coordinates may reference them
+	var managed []gopom.Dependency
+	if parentPath, parent := r.parent(p, project); parent != nil {
+		inherited, err := r.effective(ctx, parentPath, parent)
+		if err != nil {
+			return effective, err
+		}
+		for k, v := range inherited.properties {
+			effective.properties[k] = v
+		}
+		for _, v := range inherited.managed {
+			managed = append(managed, v)
+		}
+	}
+
+	for k, v := range project.Properties.Entries {
+		effective.properties[k] = v
+	}
+	groupID, artifactID, version := pomCoordinates(*project)
+	for _, prefix := range []string{"project.", "pom."} {
+		effective.properties[prefix+"groupId"] = groupID
+		effective.properties[prefix+"artifactId"] = artifactID
+		effective.properties[prefix+"version"] = version
+		effective.properties[prefix+"parent.groupId"] = project.Parent.GroupID
+		effective.properties[prefix+"parent.artifactId"] = project.Parent.ArtifactID
+		effective.properties[prefix+"parent.version"] = project.Parent.Version
+	}
+
+	var imports []gopom.Dependency
+	for _, dep := range project.DependencyManagement.Dependencies {
+		if dep.Scope == "import" {
+			imports = append(imports, dep)
+			continue
+		}
+		managed = append(managed, dep)
+	}
+	for _, dep := range managed {
+		effective.managed[effective.managedKey(dep)] = dep
+	}
+
+	// imported BOMs only manage the dependencies that are not managed otherwise, the first import taking precedence
+	for _, dep := range imports {
+		dep = effective.interpolateDependency(dep)
+		bomPath, bom := r.find(dep.GroupID, dep.ArtifactID, dep.Version)
+		if bom == nil {
+			log.Debugf("unable to find BOM %s:%s:%s imported by POM=%q", dep.GroupID, dep.ArtifactID, dep.Version, p)
+			continue
+		}
+		imported, err := r.effective(ctx, bomPath, bom)
+		if err != nil {
+			return effective, err
+		}
+		for _, v := range imported.managed {
+			v = imported.interpolateDependency(v)
+			k := effective.managedKey(v)
+			if _, ok := effective.managed[k]; !ok {
+				effective.managed[k] = v
+			}
+		}
+	}
+
+	r.effectives[p] = effective
+	return effective, nil
+}
+
+// parent finds the parent of a POM at its relative path (the POM of the parent directory by default), or else by its
+// coordinates.
+func (r *pomResolver) parent(p string, project *gopom.Project) (string, *gopom.Project) {
+	parent := project.Parent
+	if parent.ArtifactID == "" {
+		return "", nil
+	}
+
+	relativePath := parent.RelativePath
+	if relativePath == "" {
+		relativePath = "../pom.xml"
+	}
+	if !strings.HasSuffix(relativePath, ".xml") {
+		relativePath = path.Join(relativePath, "pom.xml")
+	}
+	candidatePath := path.Join(path.Dir(p), relativePath)
+	if candidate, ok := r.byPath[candidatePath]; ok {
+		groupID, artifactID, _ := pomCoordinates(*candidate)
+		if groupID == parent.GroupID && artifactID == parent.ArtifactID {
+			return candidatePath, candidate
+		}
+	}
+
+	return r.find(parent.GroupID, parent.ArtifactID, parent.Version)
+}
+
+// find looks a POM up by its coordinates among the scanned POMs and then in the local repository.
+func (r *pomResolver) find(groupID, artifactID, version string) (string, *gopom.Project) {
+	key := groupID + ":" + artifactID + ":" + version
+	if p, ok := r.byCoordinates[key]; ok {
+		return p, r.byPath[p]
+	}
+	if r.localRepository == "" || !validPomCoordinate(groupID) || !validPomCoordinate(artifactID) || !validPomCoordinate(version) {
+		return "", nil
+	}
+
+	repositoryPath := filepath.Join(r.localRepository, filepath.FromSlash(strings.ReplaceAll(groupID, ".", "/")),
+		artifactID, version, artifactID+"-"+version+".pom")
+	if rel, err := filepath.Rel(r.localRepository, repositoryPath); err != nil || rel == ".." ||
+		strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", nil
+	}
+	f, err := os.Open(repositoryPath)
+	if err != nil {
+		return "", nil
+	}
+	defer f.Close()
+
+	project, err := decodePomXML(f)
+	if err != nil {
+		log.Debugf("unable to parse POM=%q from the local repository: %+v", repositoryPath, err)
+		return "", nil
+	}
+	r.add(repositoryPath, project)
+	return repositoryPath, r.byPath[repositoryPath]
+}
+
+// validPomCoordinate tells whether a group, artifact or version read from a POM can name a path of the local
+// repository, which the POM could otherwise lead outside of (as with a parent such as "../..").
+func validPomCoordinate(value string) bool {
+	if value == "" || strings.ContainsAny(value, `/\:`) || strings.Contains(value, "${") {
+		return false
+	}
+	for _, segment := range strings.Split(value, ".") {
+		if segment == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// managedKey is the key of a managed dependency, made of its interpolated coordinates.
+func (e effectivePom) managedKey(dep gopom.Dependency) string {
+	return e.interpolate(dep.GroupID) + ":" + e.interpolate(dep.ArtifactID)
+}
+
+func (e effectivePom) interpolateDependency(dep gopom.Dependency) gopom.Dependency {
+	dep.GroupID = e.interpolate(dep.GroupID)
+	dep.ArtifactID = e.interpolate(dep.ArtifactID)
+	dep.Version = e.interpolate(dep.Version)
+	dep.Scope = e.interpolate(dep.Scope)
+	dep.Type = e.interpolate(dep.Type)
+	return dep
+}
+
+// interpolate replaces the property references of the given value, leaving references to unknown properties as they
+// are.
+func (e effectivePom) interpolate(value string) string {
+	for i := 0; i < maxPomDepth && strings.Contains(value, "${"); i++ {
+		interpolated := pomPropertyExp.ReplaceAllStringFunc(value, func(ref string) string {
+			if v, ok := e.properties[ref[2:len(ref)-1]]; ok {
+				return v
+			}
+			return ref
+		})
+		if interpolated == value {
+			break
+		}
+		value = interpolated
+	}
+	return strings.TrimSpace(value)
+}
+
+// pomCoordinates are the coordinates of a POM, which inherits its group and version from its parent when it does not
+// declare them.
+func pomCoordinates(project gopom.Project) (string, string, string) {
+	groupID, version := project.GroupID, project.Version
+	if groupID == "" {
+		groupID = project.Parent.GroupID
+	}
+	if version == "" {
+		version = project.Parent.Version
+	}
+	return groupID, project.ArtifactID, version
+}
@@ -0,0 +1,114 @@
+package java
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vifraa/gopom"
+)
+
+func TestPomResolver_FindStaysInLocalRepository(t *testing.T) {
+	root := t.TempDir()
+	repository := filepath.Join(root, "repository")
+
+	writePom := func(path, groupID, artifactID, version string) {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte("<project><groupId>"+groupID+"</groupId><artifactId>"+
+			artifactID+"</artifactId><version>"+version+"</version></project>"), 0o600))
+	}
+	writePom(filepath.Join(repository, "org", "example", "lib", "1.0", "lib-1.0.pom"), "org.example", "lib", "1.0")
+	// a POM outside of the repository, which "../.." coordinates would reach
+	writePom(filepath.Join(root, "secret", "1.0", "secret-1.0.pom"), "outside", "secret", "1.0")
+
+	tests := []struct {
+		groupID    string
+		artifactID string
+		version    string
+		found      bool
+	}{
+		{groupID: "org.example", artifactID: "lib", version: "1.0", found: true},
+		{groupID: "..", artifactID: "secret", version: "1.0"},
+		{groupID: "org", artifactID: "../../secret", version: "1.0"},
+		{groupID: "org.example", artifactID: "lib", version: "../lib/1.0"},
+		{groupID: "org.example", artifactID: "lib", version: "${project.version}"},
+	}
+	for _, test := range tests {
+		t.Run(test.groupID+":"+test.artifactID+":"+test.version, func(t *testing.T) {
+			path, project := newPomResolver(repository).find(test.groupID, test.artifactID, test.version)
+			if !test.found {
+				assert.Empty(t, path)
+				assert.Nil(t, project)
+				return
+			}
+			require.NotNil(t, project)
+			assert.Equal(t, filepath.Join(repository, "org", "example", "lib", "1.0", "lib-1.0.pom"), path)
+		})
+	}
+}
+
+func TestPomResolver_SelfImportingBOM(t *testing.T) {
+	// a BOM importing its own coordinates many times, which would recurse exponentially without the cache
+	var imports strings.Builder
+	for i := 0; i < 8; i++ {
+		imports.WriteString("<dependency><groupId>org.example</groupId><artifactId>bom</artifactId>" +
+			"<version>1.0</version><type>pom</type><scope>import</scope></dependency>")
+	}
+	project, err := decodePomXML(strings.NewReader("<project><groupId>org.example</groupId><artifactId>bom</artifactId>" +
+		"<version>1.0</version><dependencyManagement><dependencies>" + imports.String() +
+		"<dependency><groupId>org.example</groupId><artifactId>lib</artifactId><version>2.0</version></dependency>" +
+		"</dependencies></dependencyManagement><dependencies><dependency><groupId>org.example</groupId>" +
+		"<artifactId>lib</artifactId></dependency></dependencies></project>"))
+	require.NoError(t, err)
+
+	poms := newPomResolver("")
+	poms.add("pom.xml", project)
+
+	deps, err := poms.dependencies(context.Background(), "pom.xml")
+	require.NoError(t, err)
+	require.Len(t, deps, 1)
+	assert.Equal(t, "2.0", deps[0].Version)
+	assert.Equal(t, "compile", deps[0].Scope)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	cancelled := newPomResolver("")
+	cancelled.add("pom.xml", project)
+	_, err = cancelled.dependencies(ctx, "pom.xml")
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestPomResolver_PropertyValuedManagedCoordinates(t *testing.T) {
+	decode := func(content string) gopom.Project {
+		project, err := decodePomXML(strings.NewReader(content))
+		require.NoError(t, err)
+		return project
+	}
+
+	poms := newPomResolver("")
+	poms.add("pom.xml", decode(`<project><groupId>com.acme</groupId><artifactId>parent</artifactId><version>1.2.0</version>
+		<dependencyManagement><dependencies>
+			<dependency><groupId>${project.groupId}</groupId><artifactId>core</artifactId><version>${project.version}</version></dependency>
+			<dependency><groupId>com.acme</groupId><artifactId>bom</artifactId><version>3.0</version><type>pom</type><scope>import</scope></dependency>
+		</dependencies></dependencyManagement></project>`))
+	poms.add("bom/pom.xml", decode(`<project><groupId>com.acme</groupId><artifactId>bom</artifactId><version>3.0</version>
+		<properties><tools.group>com.acme.tools</tools.group></properties>
+		<dependencyManagement><dependencies>
+			<dependency><groupId>${tools.group}</groupId><artifactId>cli</artifactId><version>${project.version}</version></dependency>
+		</dependencies></dependencyManagement></project>`))
+	poms.add("core-client/pom.xml", decode(`<project><parent><groupId>com.acme</groupId><artifactId>parent</artifactId><version>1.2.0</version></parent>
+		<artifactId>core-client</artifactId><dependencies>
+			<dependency><groupId>com.acme</groupId><artifactId>core</artifactId></dependency>
+			<dependency><groupId>com.acme.tools</groupId><artifactId>cli</artifactId></dependency>
+		</dependencies></project>`))
+
+	deps, err := poms.dependencies(context.Background(), "core-client/pom.xml")
+	require.NoError(t, err)
+	require.Len(t, deps, 2)
+	assert.Equal(t, "1.2.0", deps[0].Version)
+	assert.Equal(t, "3.0", deps[1].Version)
+}
@@ -0,0 +1,387 @@
+package java
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/lovewebshell/minicat/internal/file"
+	"github.com/lovewebshell/minicat/internal/log"
+	"github.com/lovewebshell/minicat/minicat/artifact"
+	"github.com/lovewebshell/minicat/minicat/pkg"
+)
+
+const classGlob = "**/*.class"
+
+var classpathIndexGlobs = []string{
+	"/BOOT-INF/classpath.idx",
+	"/WEB-INF/classpath.idx",
+}
+
+// classRoots are the directories of an archive that classes are loaded from, rather than being part of their package.
+var classRoots = []string{
+	"BOOT-INF/classes/",
+	"WEB-INF/classes/",
+}
+
+// embeddedArtifact is an artifact an archive declares to embed: an entry of the Embedded-Artifacts or Bundle-ClassPath
+// manifest attributes, or of a Spring Boot classpath index.
+type embeddedArtifact struct {
+	path       string
+	groupID    string
+	artifactID string
+	version    string
+}
+
+// discoverShadedPkgs finds the artifacts shaded into the archive, which are left out of the nested archives. These are
+// the artifacts whose Maven metadata remains under META-INF/maven, as long as the archive holds classes of the artifact
+// itself (possibly relocated under another package), and the artifacts the archive declares to embed that are missing
+// from it.
+func (j *archiveParser) discoverShadedPkgs(parentPkg *pkg.Package) ([]*pkg.Package, []artifact.Relationship, error) {
+	if parentPkg == nil {
+		return nil, nil, nil
+	}
+
+	var pkgs []*pkg.Package
+
+	remnants, err := j.discoverMavenRemnants(parentPkg)
+	if err != nil {
+		return nil, nil, err
+	}
+	pkgs = append(pkgs, remnants...)
+
+	for _, embedded := range j.embeddedArtifacts(parentPkg) {
+		if _, ok := j.fileManifest[embedded.path]; ok {
+			continue
+		}
+		pkgs = append(pkgs, newPackageFromEmbeddedArtifact(embedded, parentPkg, j.virtualPath))
+	}
+
+	var relationships []artifact.Relationship
+	for _, p := range pkgs {
+		relationships = append(relationships, artifact.Relationship{
+			From: parentPkg,
+			To:   p,
+			Type: artifact.ContainsRelationship,
+		})
+	}
+
+	return pkgs, relationships, nil
+}
+
+func (j *archiveParser) discoverMavenRemnants(parentPkg *pkg.Package) ([]*pkg.Package, error) {
+	properties, err := pomPropertiesByParentPath(j.archivePath, j.virtualPath, j.readablePaths(parentPkg, pomPropertiesGlob))
+	if err != nil {
+		return nil, err
+	}
+	projects, err := pomProjectByParentPath(j.archivePath, j.virtualPath, j.readablePaths(parentPkg, pomXMLGlob))
+	if err != nil {
+		return nil, err
+	}
+
+	var own *pkg.PomProperties
+	if metadata, ok := parentPkg.Metadata.(pkg.JavaMetadata); ok {
+		own = metadata.PomProperties
+	}
+
+	parentPaths := make([]string, 0, len(properties))
+	for parentPath := range properties {
+		parentPaths = append(parentPaths, parentPath)
+	}
+	sort.Strings(parentPaths)
+
+	classes := archiveClasses(j.fileManifest)
+
+	var pkgs []*pkg.Package
+	for _, parentPath := range parentPaths {
+		propertiesObj := properties[parentPath]
+		if propertiesObj.ArtifactID == parentPkg.Name ||
+			own != nil && own.GroupID == propertiesObj.GroupID && own.ArtifactID == propertiesObj.ArtifactID {
+			continue
+		}
+
+		found, relocations := classesOfArtifact(classes, propertiesObj.GroupID, propertiesObj.ArtifactID)
+		if !found {
+			log.Debugf("ignoring maven metadata of %s:%s in java archive (%s): none of its classes are present",
+				propertiesObj.GroupID, propertiesObj.ArtifactID, j.virtualPath)
+			continue
+		}
+
+		var pomProject *pkg.PomProject
+		if proj, exists := projects[parentPath]; exists {
+			pomProject = &proj
+		}
+
+		pkgs = append(pkgs, &pkg.Package{
+			Name:         propertiesObj.ArtifactID,
+			Version:      propertiesObj.Version,
+			Language:     pkg.Java,
+			Type:         propertiesObj.PkgTypeIndicated(),
+			MetadataType: pkg.JavaMetadataType,
+			Metadata: pkg.JavaMetadata{
+				VirtualPath:       j.virtualPath + ":" + propertiesObj.ArtifactID,
+				PomProperties:     &propertiesObj,
+				PomProject:        pomProject,
+				RelocatedPackages: relocations,
+				Parent:            parentPkg,
+			},
+		})
+	}
+
+	return pkgs, nil
+}
+
+// embeddedArtifacts reads the artifacts declared by the Embedded-Artifacts and Bundle-ClassPath attributes of the
+// manifest (written by the maven-bundle-plugin and OSGi tooling) and by the classpath index of Spring Boot archives.
+func (j *archiveParser) embeddedArtifacts(parentPkg *pkg.Package) []embeddedArtifact {
+	var declared []embeddedArtifact
+
+	if metadata, ok := parentPkg.Metadata.(pkg.JavaMetadata); ok && metadata.Manifest != nil {
+		declared = append(declared, parseEmbeddedArtifacts(metadata.Manifest.Main["Embedded-Artifacts"])...)
+		declared = append(declared, parseBundleClassPath(metadata.Manifest.Main["Bundle-ClassPath"])...)
+	}
+
+	if indexes := j.readablePaths(parentPkg, classpathIndexGlobs...); len(indexes) > 0 {
+		contents, err := file.ContentsFromZip(j.archivePath, indexes...)
+		if err != nil {
+			log.Warnf("unable to extract classpath index (%s): %+v", j.virtualPath, err)
+		}
+		for _, index := range indexes {
+			declared = append(declared, parseClasspathIndex(contents[index])...)
+		}
+	}
+
+	var artifacts []embeddedArtifact
+	seen := make(map[string]bool)
+	for _, a := range declared {
+		if seen[a.path] {
+			continue
+		}
+		seen[a.path] = true
+		artifacts = append(artifacts, a)
+	}
+	return artifacts
+}
+
+// parseEmbeddedArtifacts reads entries such as `lib/guava-32.1.2-jre.jar;g="com.google.guava";a="guava";v="32.1.2-jre"`.
+func parseEmbeddedArtifacts(value string) []embeddedArtifact {
+	var artifacts []embeddedArtifact
+	for _, entry := range splitManifestList(value) {
+		fields := strings.Split(entry, ";")
+		a := embeddedArtifact{path: strings.TrimPrefix(strings.TrimSpace(fields[0]), "/")}
+		for _, attribute := range fields[1:] {
+			key, value, _ := strings.Cut(attribute, "=")
+			value = strings.Trim(strings.TrimSpace(value), `"`)
+			switch strings.TrimSpace(key) {
+			case "g":
+				a.groupID = value
+			case "a":
+				a.artifactID = value
+			case "v":
+				a.version = value
+			}
+		}
+		if a.path != "" {
+			artifacts = append(artifacts, a)
+		}
+	}
+	return artifacts
+}
+
+// parseBundleClassPath reads the archives of an OSGi bundle class path, leaving out the bundle itself (".") and
+// directories.
+func parseBundleClassPath(value string) []embeddedArtifact {
+	var artifacts []embeddedArtifact
+	for _, entry := range splitManifestList(value) {
+		p := strings.TrimPrefix(strings.TrimSpace(strings.Split(entry, ";")[0]), "/")
+		if isArchivePath(p) {
+			artifacts = append(artifacts, embeddedArtifact{path: p})
+		}
+	}
+	return artifacts
+}
+
+// parseClasspathIndex reads the lines of a classpath.idx file, such as `- "BOOT-INF/lib/guava-32.1.2-jre.jar"`.
+func parseClasspathIndex(contents string) []embeddedArtifact {
+	var artifacts []embeddedArtifact
+	for _, line := range strings.Split(contents, "\n") {
+		p := strings.Trim(strings.TrimPrefix(strings.TrimSpace(line), "- "), `"`)
+		if isArchivePath(p) {
+			artifacts = append(artifacts, embeddedArtifact{path: p})
+		}
+	}
+	return artifacts
+}
+
+// splitManifestList splits a manifest attribute holding a comma separated list, ignoring the commas within quotes.
+func splitManifestList(value string) []string {
+	var entries []string
+	var quoted bool
+	start := 0
+	for i, c := range value {
+		switch {
+		case c == '"':
+			quoted = !quoted
+		case c == ',' && !quoted:
+			entries = append(entries, value[start:i])
+			start = i + 1
+		}
+	}
+	if strings.TrimSpace(value[start:]) != "" {
+		entries = append(entries, value[start:])
+	}
+	return entries
+}
+
+func isArchivePath(p string) bool {
+	for _, pattern := range archiveFormatGlobs {
+		if strings.HasSuffix(p, strings.TrimPrefix(pattern, "**/*")) {
+			return true
+		}
+	}
+	return false
+}
+
+func newPackageFromEmbeddedArtifact(embedded embeddedArtifact, parentPkg *pkg.Package, virtualPath string) *pkg.Package {
+	fileInfo := newJavaArchiveFilename(embedded.path)
+	name, version := fileInfo.name, fileInfo.version
+
+	var pomProperties *pkg.PomProperties
+	if embedded.artifactID != "" {
+		name = embedded.artifactID
+		if embedded.version != "" {
+			version = embedded.version
+		}
+		pomProperties = &pkg.PomProperties{
+			Path:       embedded.path,
+			GroupID:    embedded.groupID,
+			ArtifactID: embedded.artifactID,
+			Version:    version,
+		}
+	}
+
+	return &pkg.Package{
+		Name:         name,
+		Version:      version,
+		Language:     pkg.Java,
+		Type:         fileInfo.pkgType(),
+		MetadataType: pkg.JavaMetadataType,
+		Metadata: pkg.JavaMetadata{
+			VirtualPath:   virtualPath + ":" + embedded.path,
+			PomProperties: pomProperties,
+			Parent:        parentPkg,
+		},
+	}
+}
+
+// archiveClasses are the paths of the classes of the archive, relative to the directory they are loaded from.
+func archiveClasses(manifest file.ZipFileManifest) []string {
+	var classes []string
+	for _, entry := range manifest.GlobMatch(classGlob) {
+		entry = strings.TrimPrefix(entry, "/")
+		for _, root := range classRoots {
+			entry = strings.TrimPrefix(entry, root)
+		}
+		if strings.HasPrefix(entry, "META-INF/versions/") {
+			if parts := strings.SplitN(entry, "/", 4); len(parts) == 4 {
+				entry = parts[3]
+			}
+		}
+		classes = append(classes, entry)
+	}
+	return classes
+}
+
+// groupQualifiers are the words naming an artifact as the main one of its group, whose classes are the group package.
+var groupQualifiers = map[string]bool{
+	"api":  true,
+	"core": true,
+}
+
+// artifactPackageRoots are the packages the classes of an artifact are expected under, following the convention of
+// naming them after the group and the artifact, leaving out the words of the artifact that are already part of the
+// group: io.netty:netty-handler is expected under io/netty/handler, and com.fasterxml.jackson.core:jackson-databind
+// under com/fasterxml/jackson/databind (next to a group of three segments or more). Artifacts named after their group
+// alone (com.google.guava:guava), or qualified as its API or core (org.slf4j:slf4j-api), are expected under the group
+// itself, holding their classes directly. Roots less specific than the group, or of a single package, are too broad to tell an artifact apart, so are
+// left out.
+func artifactPackageRoots(groupID, artifactID string) []packageRoot {
+	segments := strings.Split(groupID, ".")
+	inGroup := make(map[string]bool)
+	for _, s := range segments {
+		inGroup[s] = true
+	}
+
+	var words []string
+	for _, w := range strings.FieldsFunc(artifactID, func(r rune) bool { return r == '-' || r == '.' || r == '_' }) {
+		if len(words) == 0 && inGroup[w] {
+			continue
+		}
+		words = append(words, w)
+	}
+
+	var candidates [][]string
+	if len(words) > 0 {
+		candidates = append(candidates, append(append([]string(nil), segments...), words...))
+		if len(segments) >= 3 {
+			candidates = append(candidates, append(append([]string(nil), segments[:len(segments)-1]...), words...))
+		}
+	}
+	groupRoot := len(words) == 0 || len(words) == 1 && groupQualifiers[words[0]]
+	if groupRoot {
+		candidates = append(candidates, segments)
+	}
+
+	var roots []packageRoot
+	for i, root := range candidates {
+		if len(root) < 2 {
+			continue
+		}
+		roots = append(roots, packageRoot{
+			path:   strings.Join(root, "/") + "/",
+			direct: groupRoot && i == len(candidates)-1,
+		})
+	}
+	return roots
+}
+
+// packageRoot is a package the classes of an artifact are expected under. Classes are expected directly in the
+// package for the root of a whole group, whose subpackages may well be other artifacts of the group.
+type packageRoot struct {
+	path   string
+	direct bool
+}
+
+// holds tells whether a class, by its path relative to the root, belongs to the root.
+func (r packageRoot) holds(class string) bool {
+	return !r.direct || !strings.Contains(class, "/")
+}
+
+// classesOfArtifact tells whether the archive holds classes of the given artifact at its package roots, either as they
+// are or relocated under another package, in which case the relocated packages are returned.
+func classesOfArtifact(classes []string, groupID, artifactID string) (bool, []string) {
+	for _, root := range artifactPackageRoots(groupID, artifactID) {
+		var found bool
+		relocated := make(map[string]bool)
+		for _, class := range classes {
+			if strings.HasPrefix(class, root.path) {
+				if root.holds(class[len(root.path):]) {
+					found = true
+				}
+				continue
+			}
+			if i := strings.Index(class, "/"+root.path); i > 0 && root.holds(class[i+1+len(root.path):]) {
+				found = true
+				relocated[strings.ReplaceAll(class[:i+len(root.path)], "/", ".")] = true
+			}
+		}
+		if found {
+			var relocations []string
+			for r := range relocated {
+				relocations = append(relocations, r)
+			}
+			sort.Strings(relocations)
+			return true, relocations
+		}
+	}
+	return false, nil
+}
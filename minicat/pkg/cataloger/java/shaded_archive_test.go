@@ -0,0 +1,131 @@
+package java
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lovewebshell/minicat/minicat/pkg"
+)
+
+func newTestJar(t *testing.T, entries map[string]string) *bytes.Reader {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, contents := range entries {
+		f, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write([]byte(contents))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	return bytes.NewReader(buf.Bytes())
+}
+
+func TestParseJavaArchive_MavenRemnants(t *testing.T) {
+	jar := newTestJar(t, map[string]string{
+		"META-INF/MANIFEST.MF": "Manifest-Version: 1.0\n",
+		"META-INF/maven/com.alibaba.nacos/nacos-client/pom.properties": "groupId=com.alibaba.nacos\n" +
+			"artifactId=nacos-client\nversion=2.2.3\n",
+		"META-INF/maven/io.netty/netty-handler/pom.properties": "groupId=io.netty\nartifactId=netty-handler\n" +
+			"version=4.1.86.Final\n",
+		"META-INF/maven/io.netty/netty-buffer/pom.properties": "groupId=io.netty\nartifactId=netty-buffer\n" +
+			"version=4.1.86.Final\n",
+		"META-INF/maven/junit/junit/pom.properties":              "groupId=junit\nartifactId=junit\nversion=4.13.2\n",
+		"META-INF/maven/org.slf4j/slf4j-api/pom.properties":      "groupId=org.slf4j\nartifactId=slf4j-api\nversion=2.0.7\n",
+		"com/alibaba/nacos/client/NacosClient.class":             "",
+		"com/alibaba/nacos/shaded/io/netty/buffer/ByteBuf.class": "",
+		"junit/framework/TestCase.class":                         "",
+		"org/slf4j/impl/Unrelated.class":                         "",
+	})
+
+	pkgs, relationships, err := parseJavaArchive(context.Background(), "nacos-client-2.2.3.jar", jar, newArchiveBudget(DefaultArchiveLimits()), 0)
+	require.NoError(t, err)
+
+	names := make(map[string]*pkg.Package)
+	for _, p := range pkgs {
+		names[p.Name] = p
+	}
+
+	assert.Contains(t, names, "nacos-client")
+	assert.NotContains(t, names, "netty-handler", "metadata without the classes of the artifact")
+	assert.NotContains(t, names, "junit", "single package roots are too broad to tell the artifact apart")
+	assert.NotContains(t, names, "slf4j-api", "classes of the group but not of the artifact")
+
+	require.Contains(t, names, "netty-buffer")
+	metadata := names["netty-buffer"].Metadata.(pkg.JavaMetadata)
+	assert.Equal(t, []string{"com.alibaba.nacos.shaded.io.netty.buffer"}, metadata.RelocatedPackages)
+	assert.Len(t, relationships, 1)
+}
+
+func TestArtifactPackageRoots(t *testing.T) {
+	tests := []struct {
+		groupID    string
+		artifactID string
+		expected   []packageRoot
+	}{
+		{
+			groupID:    "io.netty",
+			artifactID: "netty-handler",
+			expected:   []packageRoot{{path: "io/netty/handler/"}},
+		},
+		{
+			groupID:    "com.fasterxml.jackson.core",
+			artifactID: "jackson-databind",
+			expected: []packageRoot{
+				{path: "com/fasterxml/jackson/core/databind/"},
+				{path: "com/fasterxml/jackson/databind/"},
+			},
+		},
+		{
+			groupID:    "org.apache.commons",
+			artifactID: "commons-lang3",
+			expected:   []packageRoot{{path: "org/apache/commons/lang3/"}, {path: "org/apache/lang3/"}},
+		},
+		{
+			groupID:    "com.google.guava",
+			artifactID: "guava",
+			expected:   []packageRoot{{path: "com/google/guava/", direct: true}},
+		},
+		{
+			groupID:    "org.slf4j",
+			artifactID: "slf4j-api",
+			expected:   []packageRoot{{path: "org/slf4j/api/"}, {path: "org/slf4j/", direct: true}},
+		},
+		{
+			groupID:    "org.slf4j",
+			artifactID: "slf4j-simple",
+			expected:   []packageRoot{{path: "org/slf4j/simple/"}},
+		},
+		{
+			groupID:    "junit",
+			artifactID: "junit",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.groupID+":"+test.artifactID, func(t *testing.T) {
+			assert.Equal(t, test.expected, artifactPackageRoots(test.groupID, test.artifactID))
+		})
+	}
+}
+
+func TestClassesOfArtifact_GroupRoot(t *testing.T) {
+	found, _ := classesOfArtifact([]string{"org/slf4j/Logger.class"}, "org.slf4j", "slf4j-api")
+	assert.True(t, found)
+
+	found, _ = classesOfArtifact([]string{"org/slf4j/impl/StaticLoggerBinder.class"}, "org.slf4j", "slf4j-api")
+	assert.False(t, found, "subpackages of the group may be other artifacts")
+
+	found, _ = classesOfArtifact([]string{"com/google/gson/Gson.class", "com/google/protobuf/Message.class"},
+		"com.google.guava", "guava")
+	assert.False(t, found, "classes of other artifacts sharing the parent of the group")
+
+	found, relocations := classesOfArtifact([]string{"shaded/org/slf4j/Logger.class"}, "org.slf4j", "slf4j-api")
+	assert.True(t, found)
+	assert.Equal(t, []string{"shaded.org.slf4j"}, relocations)
+}
@@ -1,8 +1,10 @@
 package java
 
 import (
+	"context"
 	"fmt"
 	"io"
+	"os"
 
 	"github.com/lovewebshell/minicat/internal/file"
 	"github.com/lovewebshell/minicat/minicat/artifact"
@@ -10,8 +12,6 @@ import (
 	"github.com/lovewebshell/minicat/minicat/pkg/cataloger/common"
 )
 
-var _ common.ParserFn = parseTarWrappedJavaArchive
-
 var genericTarGlobs = []string{
 	"**/*.tar",
 
@@ -38,7 +38,13 @@ var genericTarGlobs = []string{
 	"**/*.tar.zst",
 }
 
-func parseTarWrappedJavaArchive(virtualPath string, reader io.Reader) ([]*pkg.Package, []artifact.Relationship, error) {
+func tarWrappedJavaArchiveParser(limits ArchiveLimits) common.ParserFn {
+	return func(ctx context.Context, virtualPath string, reader io.Reader) ([]*pkg.Package, []artifact.Relationship, error) {
+		return parseTarWrappedJavaArchive(ctx, virtualPath, reader, newArchiveBudget(limits))
+	}
+}
+
+func parseTarWrappedJavaArchive(ctx context.Context, virtualPath string, reader io.Reader, budget *archiveBudget) ([]*pkg.Package, []artifact.Relationship, error) {
 	contentPath, archivePath, cleanupFn, err := saveArchiveToTmp(virtualPath, reader)
 
 	defer cleanupFn()
@@ -46,14 +52,22 @@ func parseTarWrappedJavaArchive(virtualPath string, reader io.Reader) ([]*pkg.Pa
 		return nil, nil, err
 	}
 
-	return discoverPkgsFromTar(virtualPath, archivePath, contentPath)
+	return discoverPkgsFromTar(ctx, virtualPath, archivePath, contentPath, budget)
 }
 
-func discoverPkgsFromTar(virtualPath, archivePath, contentPath string) ([]*pkg.Package, []artifact.Relationship, error) {
-	openers, err := file.ExtractGlobsFromTarToUniqueTempFile(archivePath, contentPath, archiveFormatGlobs...)
+func discoverPkgsFromTar(ctx context.Context, virtualPath, archivePath, contentPath string, budget *archiveBudget) ([]*pkg.Package, []artifact.Relationship, error) {
+	admit := func(name string, info os.FileInfo) bool {
+		if err := budget.admit(info); err != nil {
+			warnArchiveLimit(nil, virtualPath, "skipped nested archive %q: %v", name, err)
+			return false
+		}
+		return true
+	}
+
+	openers, err := file.ExtractGlobsFromTarToUniqueTempFile(ctx, archivePath, contentPath, admit, archiveFormatGlobs...)
 	if err != nil {
 		return nil, nil, fmt.Errorf("unable to extract files from tar: %w", err)
 	}
 
-	return discoverPkgsFromOpeners(virtualPath, openers, nil)
+	return discoverPkgsFromOpeners(ctx, virtualPath, openers, nil, budget, 1)
 }
@@ -1,6 +1,7 @@
 package java
 
 import (
+	"context"
 	"fmt"
 	"io"
 
@@ -10,13 +11,17 @@ import (
 	"github.com/lovewebshell/minicat/minicat/pkg/cataloger/common"
 )
 
-var _ common.ParserFn = parseZipWrappedJavaArchive
-
 var genericZipGlobs = []string{
 	"**/*.zip",
 }
 
-func parseZipWrappedJavaArchive(virtualPath string, reader io.Reader) ([]*pkg.Package, []artifact.Relationship, error) {
+func zipWrappedJavaArchiveParser(limits ArchiveLimits) common.ParserFn {
+	return func(ctx context.Context, virtualPath string, reader io.Reader) ([]*pkg.Package, []artifact.Relationship, error) {
+		return parseZipWrappedJavaArchive(ctx, virtualPath, reader, newArchiveBudget(limits))
+	}
+}
+
+func parseZipWrappedJavaArchive(ctx context.Context, virtualPath string, reader io.Reader, budget *archiveBudget) ([]*pkg.Package, []artifact.Relationship, error) {
 	contentPath, archivePath, cleanupFn, err := saveArchiveToTmp(virtualPath, reader)
 
 	defer cleanupFn()
@@ -29,5 +34,5 @@ func parseZipWrappedJavaArchive(virtualPath string, reader io.Reader) ([]*pkg.Pa
 		return nil, nil, fmt.Errorf("unable to read files from java archive: %w", err)
 	}
 
-	return discoverPkgsFromZip(virtualPath, archivePath, contentPath, fileManifest, nil)
+	return discoverPkgsFromZip(ctx, virtualPath, archivePath, contentPath, fileManifest, nil, budget, 0)
 }
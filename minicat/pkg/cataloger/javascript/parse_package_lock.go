@@ -1,9 +1,12 @@
 package javascript
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"path"
+	"sort"
 	"strings"
 
 	"github.com/lovewebshell/minicat/minicat/artifact"
@@ -24,22 +27,38 @@ type Dependency struct {
 	Version   string `json:"version"`
 	Resolved  string `json:"resolved"`
 	Integrity string `json:"integrity"`
+	Dev       bool   `json:"dev"`
+	Optional  bool   `json:"optional"`
 }
 
+// Package is an entry of the "packages" section of lockfileVersion 2 and 3 files, keyed by the path of the package
+// relative to the root of the project (for example "node_modules/a/node_modules/@scope/b"). The root project itself is
+// keyed by the empty path.
 type Package struct {
-	Version   string `json:"version"`
-	Resolved  string `json:"resolved"`
-	Integrity string `json:"integrity"`
-	License   string `json:""`
+	Name                 string            `json:"name"`
+	Version              string            `json:"version"`
+	Resolved             string            `json:"resolved"`
+	Integrity            string            `json:"integrity"`
+	License              string            `json:""`
+	Dev                  bool              `json:"dev"`
+	Optional             bool              `json:"optional"`
+	DevOptional          bool              `json:"devOptional"`
+	Peer                 bool              `json:"peer"`
+	Link                 bool              `json:"link"`
+	Dependencies         map[string]string `json:"dependencies"`
+	DevDependencies      map[string]string `json:"devDependencies"`
+	OptionalDependencies map[string]string `json:"optionalDependencies"`
+	PeerDependencies     map[string]string `json:"peerDependencies"`
 }
 
-func parsePackageLock(path string, reader io.Reader) ([]*pkg.Package, []artifact.Relationship, error) {
+func parsePackageLock(_ context.Context, path string, reader io.Reader) ([]*pkg.Package, []artifact.Relationship, error) {
 
 	if pathContainsNodeModulesDirectory(path) {
 		return nil, nil, nil
 	}
 
 	var packages []*pkg.Package
+	var relationships []artifact.Relationship
 	dec := json.NewDecoder(reader)
 
 	for {
@@ -49,31 +68,222 @@ func parsePackageLock(path string, reader io.Reader) ([]*pkg.Package, []artifact
 		} else if err != nil {
 			return nil, nil, fmt.Errorf("failed to parse package-lock.json file: %w", err)
 		}
-		licenseMap := make(map[string]string)
-		for _, pkgMeta := range lock.Packages {
-			var sb strings.Builder
-			sb.WriteString(pkgMeta.Resolved)
-			sb.WriteString(pkgMeta.Integrity)
-			licenseMap[sb.String()] = pkgMeta.License
+
+		if len(lock.Packages) > 0 {
+			pkgs, rels := parsePackageLockPackages(lock)
+			packages = append(packages, pkgs...)
+			relationships = append(relationships, rels...)
+			continue
 		}
 
 		for name, pkgMeta := range lock.Dependencies {
-			var sb strings.Builder
-			sb.WriteString(pkgMeta.Resolved)
-			sb.WriteString(pkgMeta.Integrity)
-			var licenses []string
-			if license, exists := licenseMap[sb.String()]; exists {
-				licenses = append(licenses, license)
-			}
 			packages = append(packages, &pkg.Package{
-				Name:     name,
-				Version:  pkgMeta.Version,
-				Language: pkg.JavaScript,
-				Type:     pkg.NpmPkg,
-				Licenses: licenses,
+				Name:         name,
+				Version:      pkgMeta.Version,
+				Language:     pkg.JavaScript,
+				Type:         pkg.NpmPkg,
+				MetadataType: pkg.NpmPackageLockJSONMetadataType,
+				Metadata: pkg.NpmPackageLockJSONMetadata{
+					Resolved:  pkgMeta.Resolved,
+					Integrity: pkgMeta.Integrity,
+					Dev:       pkgMeta.Dev,
+					Optional:  pkgMeta.Optional,
+				},
+			})
+		}
+	}
+
+	return packages, relationships, nil
+}
+
+// parsePackageLockPackages expresses every entry of the "packages" section as a package, relating each package to
+// the packages depending on it the same way node resolves them: from the nearest node_modules directory upwards.
+func parsePackageLockPackages(lock PackageLock) ([]*pkg.Package, []artifact.Relationship) {
+	paths := make([]string, 0, len(lock.Packages))
+	for p := range lock.Packages {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	var pkgs []*pkg.Package
+	pkgsByPath := make(map[string]*pkg.Package)
+	for _, p := range paths {
+		entry := lock.Packages[p]
+		if p == "" {
+			// the root entry is the project itself rather than one of its dependencies
+			continue
+		}
+		if entry.Link {
+			// the target of a link (usually a workspace) has an entry of its own
+			continue
+		}
+
+		name := packageLockEntryName(p, entry)
+		if name == "" || entry.Version == "" {
+			continue
+		}
+
+		var licenses []string
+		if entry.License != "" {
+			licenses = append(licenses, entry.License)
+		}
+
+		lockPkg := &pkg.Package{
+			Name:         name,
+			Version:      entry.Version,
+			Licenses:     licenses,
+			Language:     pkg.JavaScript,
+			Type:         pkg.NpmPkg,
+			MetadataType: pkg.NpmPackageLockJSONMetadataType,
+			Metadata: pkg.NpmPackageLockJSONMetadata{
+				Resolved:  entry.Resolved,
+				Integrity: entry.Integrity,
+				Dev:       entry.Dev || entry.DevOptional,
+				Optional:  entry.Optional || entry.DevOptional,
+				Peer:      entry.Peer,
+			},
+		}
+		pkgs = append(pkgs, lockPkg)
+		pkgsByPath[p] = lockPkg
+	}
+
+	var relationships []artifact.Relationship
+	seen := make(map[[2]*pkg.Package]bool)
+	addRelationships := func(from string, p *pkg.Package, dependencies map[string]string, ty artifact.RelationshipType) {
+		names := make([]string, 0, len(dependencies))
+		for name := range dependencies {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			dep, ok := pkgsByPath[resolvePackageLockDependency(lock, from, name)]
+			if !ok || seen[[2]*pkg.Package{dep, p}] {
+				continue
+			}
+			seen[[2]*pkg.Package{dep, p}] = true
+			relationships = append(relationships, artifact.Relationship{
+				From: dep,
+				To:   p,
+				Type: ty,
 			})
 		}
 	}
 
-	return packages, nil, nil
+	for _, from := range paths {
+		p, ok := pkgsByPath[from]
+		if !ok {
+			continue
+		}
+		entry := lock.Packages[from]
+		addRelationships(from, p, entry.Dependencies, artifact.RuntimeDependencyOfRelationship)
+		addRelationships(from, p, entry.OptionalDependencies, artifact.RuntimeDependencyOfRelationship)
+		addRelationships(from, p, entry.PeerDependencies, artifact.RuntimeDependencyOfRelationship)
+		addRelationships(from, p, entry.DevDependencies, artifact.DevDependencyOfRelationship)
+	}
+
+	markPackageLockDevDependencies(lock, pkgsByPath, relationships)
+
+	return pkgs, relationships
+}
+
+// markPackageLockDevDependencies marks the packages only reachable from the dev dependencies of the root project as
+// dev dependencies. The root project is not a package, so its dependencies cannot be related to it, and lockfiles do
+// not always flag the packages it only needs for development.
+func markPackageLockDevDependencies(lock PackageLock, pkgsByPath map[string]*pkg.Package, relationships []artifact.Relationship) {
+	root := lock.Packages[""]
+	if len(root.DevDependencies) == 0 {
+		return
+	}
+
+	graph := make(map[*pkg.Package][]*pkg.Package)
+	for _, r := range relationships {
+		if r.Type != artifact.RuntimeDependencyOfRelationship {
+			continue
+		}
+		dependent, dependency := r.To.(*pkg.Package), r.From.(*pkg.Package)
+		graph[dependent] = append(graph[dependent], dependency)
+	}
+
+	resolve := func(dependencies ...map[string]string) []*pkg.Package {
+		var resolved []*pkg.Package
+		for _, deps := range dependencies {
+			for name := range deps {
+				if p, ok := pkgsByPath[resolvePackageLockDependency(lock, "", name)]; ok {
+					resolved = append(resolved, p)
+				}
+			}
+		}
+		return resolved
+	}
+
+	prodRoots := resolve(root.Dependencies, root.OptionalDependencies, root.PeerDependencies)
+	for p, lockPkg := range pkgsByPath {
+		// workspaces are part of the project rather than dependencies of it
+		if !strings.Contains(p, "node_modules/") && !lock.Packages[p].Dev {
+			prodRoots = append(prodRoots, lockPkg)
+		}
+	}
+	prod := reachablePackages(graph, prodRoots)
+	dev := reachablePackages(graph, resolve(root.DevDependencies))
+
+	for lockPkg := range dev {
+		if prod[lockPkg] {
+			continue
+		}
+		if metadata, ok := lockPkg.Metadata.(pkg.NpmPackageLockJSONMetadata); ok {
+			metadata.Dev = true
+			lockPkg.Metadata = metadata
+		}
+	}
+}
+
+func reachablePackages(graph map[*pkg.Package][]*pkg.Package, roots []*pkg.Package) map[*pkg.Package]bool {
+	reached := make(map[*pkg.Package]bool)
+	queue := append([]*pkg.Package(nil), roots...)
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+		if reached[p] {
+			continue
+		}
+		reached[p] = true
+		queue = append(queue, graph[p]...)
+	}
+	return reached
+}
+
+// packageLockEntryName is the name of the package installed at the given path, which is only recorded in the entry
+// itself for workspaces and aliased packages.
+func packageLockEntryName(p string, entry Package) string {
+	if entry.Name != "" {
+		return entry.Name
+	}
+	if i := strings.LastIndex(p, "node_modules/"); i >= 0 {
+		return p[i+len("node_modules/"):]
+	}
+	return path.Base(p)
+}
+
+// resolvePackageLockDependency finds the path of the package with the given name as required by the package at the
+// given path, following links to their targets. An empty path is returned if there is no such package.
+func resolvePackageLockDependency(lock PackageLock, from, name string) string {
+	dir := from
+	for {
+		candidate := path.Join(dir, "node_modules", name)
+		if entry, ok := lock.Packages[candidate]; ok {
+			if entry.Link {
+				return entry.Resolved
+			}
+			return candidate
+		}
+		if dir == "" {
+			return ""
+		}
+		if i := strings.LastIndex(dir, "node_modules/"); i >= 0 {
+			dir = strings.TrimSuffix(dir[:i], "/")
+		} else {
+			dir = ""
+		}
+	}
 }
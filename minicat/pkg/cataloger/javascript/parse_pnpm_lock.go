@@ -1,8 +1,11 @@
 package javascript
 
 import (
+	"context"
 	"fmt"
 	"io"
+	"sort"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 
@@ -13,31 +16,288 @@ import (
 
 var _ common.ParserFn = parsePnpmLock
 
+// pnpmLockYaml covers lockfile versions 5, 6 and 9. Single project lock files before version 9 list the dependencies
+// of the project at the top level instead of under an importer, and version 9 moves the dependency graph out of
+// "packages" into "snapshots".
 type pnpmLockYaml struct {
-	Dependencies map[string]string `json:"dependencies"`
+	LockfileVersion      string                       `yaml:"lockfileVersion"`
+	Dependencies         map[string]pnpmDependencyRef `yaml:"dependencies"`
+	DevDependencies      map[string]pnpmDependencyRef `yaml:"devDependencies"`
+	OptionalDependencies map[string]pnpmDependencyRef `yaml:"optionalDependencies"`
+	Importers            map[string]pnpmImporter      `yaml:"importers"`
+	Packages             map[string]pnpmPackage       `yaml:"packages"`
+	Snapshots            map[string]pnpmSnapshot      `yaml:"snapshots"`
 }
 
-func parsePnpmLock(path string, reader io.Reader) ([]*pkg.Package, []artifact.Relationship, error) {
+type pnpmImporter struct {
+	Dependencies         map[string]pnpmDependencyRef `yaml:"dependencies"`
+	DevDependencies      map[string]pnpmDependencyRef `yaml:"devDependencies"`
+	OptionalDependencies map[string]pnpmDependencyRef `yaml:"optionalDependencies"`
+}
+
+type pnpmPackage struct {
+	Name                 string            `yaml:"name"`
+	Version              string            `yaml:"version"`
+	Resolution           pnpmResolution    `yaml:"resolution"`
+	Dev                  *bool             `yaml:"dev"`
+	Optional             bool              `yaml:"optional"`
+	Dependencies         map[string]string `yaml:"dependencies"`
+	OptionalDependencies map[string]string `yaml:"optionalDependencies"`
+}
+
+type pnpmSnapshot struct {
+	Optional             bool              `yaml:"optional"`
+	Dependencies         map[string]string `yaml:"dependencies"`
+	OptionalDependencies map[string]string `yaml:"optionalDependencies"`
+}
+
+type pnpmResolution struct {
+	Integrity string `yaml:"integrity"`
+	Tarball   string `yaml:"tarball"`
+}
+
+// pnpmDependencyRef is the resolved version of a dependency of an importer, given as a plain string before version 6
+// and as a mapping holding the specifier and the version since.
+type pnpmDependencyRef string
+
+func (r *pnpmDependencyRef) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		*r = pnpmDependencyRef(value.Value)
+		return nil
+	}
+	var ref struct {
+		Version string `yaml:"version"`
+	}
+	if err := value.Decode(&ref); err != nil {
+		return err
+	}
+	*r = pnpmDependencyRef(ref.Version)
+	return nil
+}
+
+type pnpmPackageID struct {
+	name    string
+	version string
+}
+
+func parsePnpmLock(_ context.Context, path string, reader io.Reader) ([]*pkg.Package, []artifact.Relationship, error) {
 	bytes, err := io.ReadAll(reader)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to load pnpm-lock.yaml file: %w", err)
 	}
 
-	var pkgs []*pkg.Package
 	var lockFile pnpmLockYaml
 
 	if err := yaml.Unmarshal(bytes, &lockFile); err != nil {
 		return nil, nil, fmt.Errorf("failed to parse pnpm-lock.yaml file: %w", err)
 	}
 
-	for name, version := range lockFile.Dependencies {
-		pkgs = append(pkgs, &pkg.Package{
-			Name:     name,
-			Version:  version,
-			Language: pkg.JavaScript,
-			Type:     pkg.NpmPkg,
-		})
+	v5 := strings.HasPrefix(lockFile.LockfileVersion, "5")
+
+	importers := lockFile.Importers
+	if len(importers) == 0 {
+		importers = map[string]pnpmImporter{
+			".": {
+				Dependencies:         lockFile.Dependencies,
+				DevDependencies:      lockFile.DevDependencies,
+				OptionalDependencies: lockFile.OptionalDependencies,
+			},
+		}
+	}
+
+	entries := make(map[pnpmPackageID]*pnpmPackage)
+	for key, entry := range lockFile.Packages {
+		entry := entry
+		id := parsePnpmPackageKey(key, v5)
+		if entry.Name != "" && entry.Version != "" {
+			id = pnpmPackageID{name: entry.Name, version: entry.Version}
+		}
+		if id.name == "" || id.version == "" {
+			continue
+		}
+		// the same package may be listed once for every set of peer dependencies it was resolved with, where it is
+		// only a dev dependency if it is one in every listing
+		if existing, ok := entries[id]; ok {
+			if existing.Dev != nil && entry.Dev != nil && !*entry.Dev {
+				existing.Dev = entry.Dev
+			}
+			continue
+		}
+		entries[id] = &entry
+	}
+
+	graph := make(map[pnpmPackageID][]pnpmPackageID)
+	addEdges := func(key string, dependencies ...map[string]string) {
+		from := parsePnpmPackageKey(key, v5)
+		for _, deps := range dependencies {
+			for name, ref := range deps {
+				if to, ok := resolvePnpmDependency(name, ref, v5); ok {
+					graph[from] = append(graph[from], to)
+				}
+			}
+		}
+	}
+	if len(lockFile.Snapshots) > 0 {
+		for key, snapshot := range lockFile.Snapshots {
+			addEdges(key, snapshot.Dependencies, snapshot.OptionalDependencies)
+		}
+	} else {
+		for key, entry := range lockFile.Packages {
+			addEdges(key, entry.Dependencies, entry.OptionalDependencies)
+		}
+	}
+
+	// packages only reachable from the dev dependencies of the importers are dev dependencies, which is the only way
+	// to tell since version 9 dropped the dev flag
+	var prodRoots, devRoots []pnpmPackageID
+	for _, importer := range importers {
+		prodRoots = append(prodRoots, resolvePnpmImporterDependencies(importer.Dependencies, v5)...)
+		prodRoots = append(prodRoots, resolvePnpmImporterDependencies(importer.OptionalDependencies, v5)...)
+		devRoots = append(devRoots, resolvePnpmImporterDependencies(importer.DevDependencies, v5)...)
+	}
+	prod := reachablePnpmPackages(graph, prodRoots)
+
+	// dependencies of importers are listed in the packages section, unless the lock file predates it
+	for _, id := range append(prodRoots, devRoots...) {
+		if _, ok := entries[id]; !ok {
+			entries[id] = &pnpmPackage{}
+		}
 	}
 
-	return pkgs, nil, nil
+	ids := make([]pnpmPackageID, 0, len(entries))
+	for id := range entries {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if ids[i].name != ids[j].name {
+			return ids[i].name < ids[j].name
+		}
+		return ids[i].version < ids[j].version
+	})
+
+	var pkgs []*pkg.Package
+	pkgsByID := make(map[pnpmPackageID]*pkg.Package)
+	for _, id := range ids {
+		entry := entries[id]
+		dev := !prod[id]
+		if entry.Dev != nil {
+			dev = *entry.Dev
+		}
+
+		p := &pkg.Package{
+			Name:         id.name,
+			Version:      id.version,
+			Language:     pkg.JavaScript,
+			Type:         pkg.NpmPkg,
+			MetadataType: pkg.PnpmLockMetadataType,
+			Metadata: pkg.PnpmLockMetadata{
+				Integrity: entry.Resolution.Integrity,
+				Tarball:   entry.Resolution.Tarball,
+				Dev:       dev,
+				Optional:  entry.Optional,
+			},
+		}
+		pkgs = append(pkgs, p)
+		pkgsByID[id] = p
+	}
+
+	var relationships []artifact.Relationship
+	seen := make(map[[2]pnpmPackageID]bool)
+	for _, from := range ids {
+		for _, to := range graph[from] {
+			dep, ok := pkgsByID[to]
+			if !ok || seen[[2]pnpmPackageID{to, from}] {
+				continue
+			}
+			seen[[2]pnpmPackageID{to, from}] = true
+			relationships = append(relationships, artifact.Relationship{
+				From: dep,
+				To:   pkgsByID[from],
+				Type: artifact.RuntimeDependencyOfRelationship,
+			})
+		}
+	}
+
+	return pkgs, relationships, nil
+}
+
+// parsePnpmPackageKey splits a key of the packages (or snapshots) section into the package name and version, dropping
+// the peer dependencies the package was resolved with: "/name/1.0.0_peer@2.0.0" (version 5),
+// "/name@1.0.0(peer@2.0.0)" (version 6) and "name@1.0.0(peer@2.0.0)" (version 9).
+func parsePnpmPackageKey(key string, v5 bool) pnpmPackageID {
+	key = strings.TrimPrefix(key, "/")
+
+	if v5 {
+		fields := strings.SplitN(key, "/", 2)
+		if strings.HasPrefix(key, "@") {
+			fields = strings.SplitN(key, "/", 3)
+			if len(fields) == 3 {
+				fields = []string{fields[0] + "/" + fields[1], fields[2]}
+			}
+		}
+		if len(fields) != 2 {
+			return pnpmPackageID{}
+		}
+		return pnpmPackageID{
+			name:    fields[0],
+			version: strings.SplitN(fields[1], "_", 2)[0],
+		}
+	}
+
+	key = strings.SplitN(key, "(", 2)[0]
+	i := strings.LastIndex(key, "@")
+	if i <= 0 {
+		return pnpmPackageID{}
+	}
+	return pnpmPackageID{
+		name:    key[:i],
+		version: key[i+1:],
+	}
+}
+
+// resolvePnpmDependency finds the package a dependency refers to, which is either the version of the package of the
+// given name (possibly with a peer dependency suffix) or, for aliased dependencies, the key of another package.
+// Dependencies on local directories are not resolved.
+func resolvePnpmDependency(name, ref string, v5 bool) (pnpmPackageID, bool) {
+	if ref == "" || strings.HasPrefix(ref, "link:") || strings.HasPrefix(ref, "file:") {
+		return pnpmPackageID{}, false
+	}
+
+	var id pnpmPackageID
+	switch {
+	case strings.HasPrefix(ref, "/"):
+		id = parsePnpmPackageKey(ref, v5)
+	case !v5 && strings.Index(strings.SplitN(ref, "(", 2)[0], "@") > 0:
+		id = parsePnpmPackageKey(ref, v5)
+	case v5:
+		id = pnpmPackageID{name: name, version: strings.SplitN(ref, "_", 2)[0]}
+	default:
+		id = pnpmPackageID{name: name, version: strings.SplitN(ref, "(", 2)[0]}
+	}
+	return id, id.name != "" && id.version != ""
+}
+
+func resolvePnpmImporterDependencies(dependencies map[string]pnpmDependencyRef, v5 bool) []pnpmPackageID {
+	var ids []pnpmPackageID
+	for name, ref := range dependencies {
+		if id, ok := resolvePnpmDependency(name, string(ref), v5); ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func reachablePnpmPackages(graph map[pnpmPackageID][]pnpmPackageID, roots []pnpmPackageID) map[pnpmPackageID]bool {
+	reached := make(map[pnpmPackageID]bool)
+	queue := append([]pnpmPackageID(nil), roots...)
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if reached[id] {
+			continue
+		}
+		reached[id] = true
+		queue = append(queue, graph[id]...)
+	}
+	return reached
 }
@@ -0,0 +1,181 @@
+package javascript
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/lovewebshell/minicat/minicat/artifact"
+	"github.com/lovewebshell/minicat/minicat/pkg"
+)
+
+var yarnProtocolExp = regexp.MustCompile(`^[a-z][a-z+-]*:`)
+
+type yarnBerryEntry struct {
+	Version              string            `yaml:"version"`
+	Resolution           string            `yaml:"resolution"`
+	Dependencies         map[string]string `yaml:"dependencies"`
+	OptionalDependencies map[string]string `yaml:"optionalDependencies"`
+	Checksum             string            `yaml:"checksum"`
+	LanguageName         string            `yaml:"languageName"`
+}
+
+// isYarnBerryLock tells the YAML lock files of yarn 2 and later apart from the yarn classic format, which has no
+// "__metadata" entry.
+func isYarnBerryLock(contents []byte) bool {
+	for _, line := range strings.Split(string(contents), "\n") {
+		if strings.HasPrefix(line, "__metadata:") {
+			return true
+		}
+	}
+	return false
+}
+
+// parseYarnBerryLock reads a lock file of yarn 2 or later. Every entry is keyed by the comma separated descriptors
+// ("name@npm:^1.0.0") resolving to it, which is how the dependencies of other entries are related to it.
+func parseYarnBerryLock(contents []byte) ([]*pkg.Package, []artifact.Relationship, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(contents, &document); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse yarn.lock file: %w", err)
+	}
+	if len(document.Content) == 0 || document.Content[0].Kind != yaml.MappingNode {
+		return nil, nil, nil
+	}
+
+	// entries are walked by hand since the same key may be repeated, which yaml.v3 refuses to decode into a map
+	root := document.Content[0]
+	entries := make(map[string]yarnBerryEntry)
+	descriptors := make(map[string]string)
+	var resolutions []string
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key := root.Content[i].Value
+		if key == "__metadata" {
+			continue
+		}
+
+		var entry yarnBerryEntry
+		if err := root.Content[i+1].Decode(&entry); err != nil {
+			return nil, nil, fmt.Errorf("failed to parse yarn.lock entry %q: %w", key, err)
+		}
+		if entry.Resolution == "" {
+			continue
+		}
+
+		if _, ok := entries[entry.Resolution]; !ok {
+			resolutions = append(resolutions, entry.Resolution)
+		}
+		entries[entry.Resolution] = entry
+		for _, descriptor := range strings.Split(key, ",") {
+			descriptors[strings.TrimSpace(descriptor)] = entry.Resolution
+		}
+	}
+	sort.Strings(resolutions)
+
+	// a patched package is the same package as the one it patches
+	var packages []*pkg.Package
+	pkgsByID := make(map[string]*pkg.Package)
+	pkgsByResolution := make(map[string]*pkg.Package)
+	for _, resolution := range resolutions {
+		entry := entries[resolution]
+		name, version, ok := yarnBerryPackage(resolution, entry.Version)
+		if !ok {
+			continue
+		}
+
+		id := name + "@" + version
+		if p, ok := pkgsByID[id]; ok {
+			pkgsByResolution[resolution] = p
+			continue
+		}
+
+		p := &pkg.Package{
+			Name:         name,
+			Version:      version,
+			Language:     pkg.JavaScript,
+			Type:         pkg.NpmPkg,
+			MetadataType: pkg.YarnLockMetadataType,
+			Metadata: pkg.YarnLockMetadata{
+				Resolution: resolution,
+				Checksum:   entry.Checksum,
+			},
+		}
+		packages = append(packages, p)
+		pkgsByID[id] = p
+		pkgsByResolution[resolution] = p
+	}
+
+	var relationships []artifact.Relationship
+	seen := make(map[[2]*pkg.Package]bool)
+	for _, resolution := range resolutions {
+		p, ok := pkgsByResolution[resolution]
+		if !ok {
+			continue
+		}
+		entry := entries[resolution]
+		for _, deps := range []map[string]string{entry.Dependencies, entry.OptionalDependencies} {
+			names := make([]string, 0, len(deps))
+			for name := range deps {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			for _, name := range names {
+				dep, ok := pkgsByResolution[descriptors[yarnBerryDescriptor(name, deps[name])]]
+				if !ok || dep == p || seen[[2]*pkg.Package{dep, p}] {
+					continue
+				}
+				seen[[2]*pkg.Package{dep, p}] = true
+				relationships = append(relationships, artifact.Relationship{
+					From: dep,
+					To:   p,
+					Type: artifact.RuntimeDependencyOfRelationship,
+				})
+			}
+		}
+	}
+
+	return packages, relationships, nil
+}
+
+// yarnBerryPackage finds the name and version of the package behind a resolution, unwrapping patches
+// ("name@patch:name@npm%3A1.0.0#./fix.patch::locator=...") to the package they patch. Workspaces and other
+// packages of the project itself are not packages of their own.
+func yarnBerryPackage(resolution, version string) (string, string, bool) {
+	i := strings.Index(resolution[1:], "@")
+	if i < 0 {
+		return "", "", false
+	}
+	name, reference := resolution[:i+1], resolution[i+2:]
+
+	switch {
+	case strings.HasPrefix(reference, "workspace:"), strings.HasPrefix(reference, "portal:"),
+		strings.HasPrefix(reference, "link:"):
+		return "", "", false
+	case strings.HasPrefix(reference, "patch:"):
+		patched := strings.SplitN(strings.TrimPrefix(reference, "patch:"), "#", 2)[0]
+		if unescaped, err := url.QueryUnescape(patched); err == nil {
+			patched = unescaped
+		}
+		return yarnBerryPackage(patched, version)
+	case version == "" && strings.HasPrefix(reference, "npm:"):
+		version = strings.TrimPrefix(reference, "npm:")
+	}
+
+	if version == "" || version == "0.0.0-use.local" {
+		return "", "", false
+	}
+	return name, version, true
+}
+
+// yarnBerryDescriptor is the descriptor a dependency is keyed by in the lock file, where ranges without a protocol
+// are npm ranges.
+func yarnBerryDescriptor(name, rng string) string {
+	if !yarnProtocolExp.MatchString(rng) {
+		rng = "npm:" + rng
+	}
+	return name + "@" + rng
+}
@@ -2,6 +2,8 @@ package javascript
 
 import (
 	"bufio"
+	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"regexp"
@@ -28,14 +30,23 @@ const (
 	noVersion = ""
 )
 
-func parseYarnLock(path string, reader io.Reader) ([]*pkg.Package, []artifact.Relationship, error) {
+func parseYarnLock(_ context.Context, path string, reader io.Reader) ([]*pkg.Package, []artifact.Relationship, error) {
 
 	if pathContainsNodeModulesDirectory(path) {
 		return nil, nil, nil
 	}
 
+	contents, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load yarn.lock file: %w", err)
+	}
+
+	if isYarnBerryLock(contents) {
+		return parseYarnBerryLock(contents)
+	}
+
 	var packages []*pkg.Package
-	scanner := bufio.NewScanner(reader)
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
 	parsedPackages := internal.NewStringSet()
 	currentPackage := noPackage
 	currentVersion := noVersion
@@ -0,0 +1,25 @@
+/*
+Package php provides concrete Cataloger implementations for PHP ecosystem files (composer.lock and the installed.json
+written by composer into the vendor directory).
+*/
+package php
+
+import (
+	"github.com/lovewebshell/minicat/minicat/pkg/cataloger/common"
+)
+
+func NewPHPComposerLockCataloger() *common.GenericCataloger {
+	globParsers := map[string]common.ParserFn{
+		"**/composer.lock": parseComposerLock,
+	}
+
+	return common.NewGenericCataloger(nil, globParsers, "php-composer-lock-cataloger")
+}
+
+func NewPHPComposerInstalledCataloger() *common.GenericCataloger {
+	globParsers := map[string]common.ParserFn{
+		"**/vendor/composer/installed.json": parseInstalledJSON,
+	}
+
+	return common.NewGenericCataloger(nil, globParsers, "php-composer-installed-cataloger")
+}
@@ -0,0 +1,177 @@
+package php
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/lovewebshell/minicat/minicat/artifact"
+	"github.com/lovewebshell/minicat/minicat/pkg"
+)
+
+type composerLock struct {
+	Packages    []composerPackage `json:"packages"`
+	PackagesDev []composerPackage `json:"packages-dev"`
+}
+
+// installedJSON is the vendor/composer/installed.json written by composer 2; composer 1 writes the bare package list.
+type installedJSON struct {
+	Packages []composerPackage `json:"packages"`
+}
+
+type composerPackage struct {
+	Name            string                           `json:"name"`
+	Version         string                           `json:"version"`
+	Source          pkg.PhpComposerExternalReference `json:"source"`
+	Dist            pkg.PhpComposerExternalReference `json:"dist"`
+	Require         composerLinks                    `json:"require"`
+	Provide         composerLinks                    `json:"provide"`
+	RequireDev      composerLinks                    `json:"require-dev"`
+	Suggest         composerLinks                    `json:"suggest"`
+	License         composerStrings                  `json:"license"`
+	Type            string                           `json:"type"`
+	NotificationURL string                           `json:"notification-url"`
+	Bin             composerStrings                  `json:"bin"`
+	Authors         []pkg.PhpComposerAuthors         `json:"authors"`
+	Description     string                           `json:"description"`
+	Homepage        string                           `json:"homepage"`
+	Keywords        composerStrings                  `json:"keywords"`
+	Time            string                           `json:"time"`
+}
+
+// composerLinks is a package link map (require, provide, ...), which PHP encodes as an empty list when there are no
+// links.
+type composerLinks map[string]string
+
+func (l *composerLinks) UnmarshalJSON(data []byte) error {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("[")) {
+		*l = nil
+		return nil
+	}
+	var links map[string]string
+	if err := json.Unmarshal(data, &links); err != nil {
+		return err
+	}
+	*l = links
+	return nil
+}
+
+// composerStrings is a list of strings that may also be given as a single string.
+type composerStrings []string
+
+func (s *composerStrings) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		if single != "" {
+			*s = []string{single}
+		}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*s = list
+	return nil
+}
+
+func parseComposerLock(_ context.Context, _ string, reader io.Reader) ([]*pkg.Package, []artifact.Relationship, error) {
+	var lock composerLock
+	if err := json.NewDecoder(reader).Decode(&lock); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse composer.lock file: %w", err)
+	}
+
+	pkgs := newComposerPackages(lock.Packages)
+	return pkgs, composerRelationships(pkgs), nil
+}
+
+func parseInstalledJSON(_ context.Context, _ string, reader io.Reader) ([]*pkg.Package, []artifact.Relationship, error) {
+	contents, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read installed.json file: %w", err)
+	}
+
+	var packages []composerPackage
+	if bytes.HasPrefix(bytes.TrimSpace(contents), []byte("[")) {
+		err = json.Unmarshal(contents, &packages)
+	} else {
+		var installed installedJSON
+		err = json.Unmarshal(contents, &installed)
+		packages = installed.Packages
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse installed.json file: %w", err)
+	}
+
+	pkgs := newComposerPackages(packages)
+	return pkgs, composerRelationships(pkgs), nil
+}
+
+func newComposerPackages(packages []composerPackage) []*pkg.Package {
+	var pkgs []*pkg.Package
+	for _, p := range packages {
+		if p.Name == "" || p.Version == "" {
+			continue
+		}
+		pkgs = append(pkgs, &pkg.Package{
+			Name:         p.Name,
+			Version:      p.Version,
+			Licenses:     p.License,
+			Language:     pkg.PHP,
+			Type:         pkg.PhpComposerPkg,
+			MetadataType: pkg.PhpComposerJSONMetadataType,
+			Metadata: pkg.PhpComposerJSONMetadata{
+				Name:            p.Name,
+				Version:         p.Version,
+				Source:          p.Source,
+				Dist:            p.Dist,
+				Require:         p.Require,
+				Provide:         p.Provide,
+				RequireDev:      p.RequireDev,
+				Suggest:         p.Suggest,
+				License:         p.License,
+				Type:            p.Type,
+				NotificationURL: p.NotificationURL,
+				Bin:             p.Bin,
+				Authors:         p.Authors,
+				Description:     p.Description,
+				Homepage:        p.Homepage,
+				Keywords:        p.Keywords,
+				Time:            p.Time,
+			},
+		})
+	}
+	return pkgs
+}
+
+// composerRelationships relates every package to the packages requiring it. Requirements on the platform (php itself,
+// extensions and libraries) or on packages not in the file are not related.
+func composerRelationships(pkgs []*pkg.Package) []artifact.Relationship {
+	byName := make(map[string]*pkg.Package)
+	for _, p := range pkgs {
+		byName[strings.ToLower(p.Name)] = p
+	}
+
+	var relationships []artifact.Relationship
+	for _, p := range pkgs {
+		m, ok := p.Metadata.(pkg.PhpComposerJSONMetadata)
+		if !ok {
+			continue
+		}
+		for name := range m.Require {
+			dep, ok := byName[strings.ToLower(name)]
+			if !ok {
+				continue
+			}
+			relationships = append(relationships, artifact.Relationship{
+				From: dep,
+				To:   p,
+				Type: artifact.DependencyOfRelationship,
+			})
+		}
+	}
+	return relationships
+}
@@ -1,5 +1,5 @@
 /*
-Package python provides a concrete Cataloger implementation for Python ecosystem files (egg, wheel, requirements.txt).
+Package python provides a concrete Cataloger implementation for Python ecosystem files (egg, wheel, requirements.txt, pyproject.toml and lock files).
 */
 package python
 
@@ -13,6 +13,9 @@ func NewPythonIndexCataloger() *common.GenericCataloger {
 		"**/poetry.lock":        parsePoetryLock,
 		"**/Pipfile.lock":       parsePipfileLock,
 		"**/setup.py":           parseSetup,
+		"**/pyproject.toml":     parsePyproject,
+		"**/uv.lock":            parseUvLock,
+		"**/pdm.lock":           parsePdmLock,
 	}
 
 	return common.NewGenericCataloger(nil, globParsers, "python-index-cataloger")
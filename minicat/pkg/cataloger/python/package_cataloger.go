@@ -2,6 +2,7 @@ package python
 
 import (
 	"bufio"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -30,11 +31,11 @@ func (c *PackageCataloger) Name() string {
 	return "python-package-cataloger"
 }
 
-func (c *PackageCataloger) Catalog(resolver source.FileResolver) ([]pkg.Package, []artifact.Relationship, error) {
+func (c *PackageCataloger) Catalog(ctx context.Context, resolver source.FileResolver) ([]pkg.Package, []artifact.Relationship, error) {
 	var fileMatches []source.Location
 
 	for _, glob := range []string{eggMetadataGlob, wheelMetadataGlob, eggFileMetadataGlob} {
-		matches, err := resolver.FilesByGlob(glob)
+		matches, err := resolver.FilesByGlob(ctx, glob)
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to find files by glob: %s", glob)
 		}
@@ -43,6 +44,9 @@ func (c *PackageCataloger) Catalog(resolver source.FileResolver) ([]pkg.Package,
 
 	var pkgs []pkg.Package
 	for _, location := range fileMatches {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
 		p, err := c.catalogEggOrWheel(resolver, location)
 		if err != nil {
 			return nil, nil, fmt.Errorf("unable to catalog python package=%+v: %w", location.RealPath, err)
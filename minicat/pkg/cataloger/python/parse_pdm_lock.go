@@ -0,0 +1,110 @@
+package python
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/pelletier/go-toml"
+
+	"github.com/lovewebshell/minicat/minicat/artifact"
+	"github.com/lovewebshell/minicat/minicat/pkg"
+	"github.com/lovewebshell/minicat/minicat/pkg/cataloger/common"
+)
+
+var _ common.ParserFn = parsePdmLock
+
+type pdmLock struct {
+	Packages []pdmLockPackage `toml:"package"`
+}
+
+type pdmLockPackage struct {
+	Name         string   `toml:"name"`
+	Version      string   `toml:"version"`
+	Groups       []string `toml:"groups"`
+	Marker       string   `toml:"marker"`
+	Editable     bool     `toml:"editable"`
+	Dependencies []string `toml:"dependencies"`
+	Files        []struct {
+		Hash string `toml:"hash"`
+	} `toml:"files"`
+}
+
+// parsePdmLock reads the packages resolved by pdm, where a package installed with extras is listed once more for
+// every set of extras it is installed with.
+func parsePdmLock(_ context.Context, _ string, reader io.Reader) ([]*pkg.Package, []artifact.Relationship, error) {
+	tree, err := toml.LoadReader(reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to load pdm.lock for parsing: %v", err)
+	}
+
+	var lock pdmLock
+	if err := tree.Unmarshal(&lock); err != nil {
+		return nil, nil, fmt.Errorf("unable to parse pdm.lock: %v", err)
+	}
+
+	var pkgs []*pkg.Package
+	pkgsByEntry := make(map[int]*pkg.Package)
+	pkgsByName := make(map[string]*pkg.Package)
+	for i, entry := range lock.Packages {
+		if entry.Editable || entry.Name == "" {
+			continue
+		}
+
+		name := normalizeName(entry.Name)
+		if p, ok := pkgsByName[name]; ok && p.Version == entry.Version {
+			pkgsByEntry[i] = p
+			continue
+		}
+
+		var hashes []string
+		for _, file := range entry.Files {
+			if file.Hash != "" {
+				hashes = append(hashes, file.Hash)
+			}
+		}
+
+		p := &pkg.Package{
+			Name:         entry.Name,
+			Version:      entry.Version,
+			Language:     pkg.Python,
+			Type:         pkg.PythonPkg,
+			MetadataType: pkg.PythonPdmLockMetadataType,
+			Metadata: pkg.PythonPdmLockMetadata{
+				Groups:  entry.Groups,
+				Markers: entry.Marker,
+				Hashes:  hashes,
+			},
+		}
+		pkgs = append(pkgs, p)
+		pkgsByEntry[i] = p
+		pkgsByName[name] = p
+	}
+
+	var relationships []artifact.Relationship
+	seen := make(map[[2]*pkg.Package]bool)
+	for i, entry := range lock.Packages {
+		p, ok := pkgsByEntry[i]
+		if !ok {
+			continue
+		}
+		for _, s := range entry.Dependencies {
+			req, ok := parseRequirement(s)
+			if !ok {
+				continue
+			}
+			dep, ok := pkgsByName[normalizeName(req.name)]
+			if !ok || dep == p || seen[[2]*pkg.Package{dep, p}] {
+				continue
+			}
+			seen[[2]*pkg.Package{dep, p}] = true
+			relationships = append(relationships, artifact.Relationship{
+				From: dep,
+				To:   p,
+				Type: artifact.RuntimeDependencyOfRelationship,
+			})
+		}
+	}
+
+	return pkgs, relationships, nil
+}
@@ -1,6 +1,7 @@
 package python
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -37,7 +38,7 @@ type Dependency struct {
 
 var _ common.ParserFn = parsePipfileLock
 
-func parsePipfileLock(_ string, reader io.Reader) ([]*pkg.Package, []artifact.Relationship, error) {
+func parsePipfileLock(_ context.Context, _ string, reader io.Reader) ([]*pkg.Package, []artifact.Relationship, error) {
 	packages := make([]*pkg.Package, 0)
 	dec := json.NewDecoder(reader)
 
@@ -0,0 +1,95 @@
+package python
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/pelletier/go-toml"
+
+	"github.com/lovewebshell/minicat/minicat/artifact"
+	"github.com/lovewebshell/minicat/minicat/pkg"
+	"github.com/lovewebshell/minicat/minicat/pkg/cataloger/common"
+)
+
+var _ common.ParserFn = parsePyproject
+
+type pyprojectToml struct {
+	Project struct {
+		Dependencies         []string            `toml:"dependencies"`
+		OptionalDependencies map[string][]string `toml:"optional-dependencies"`
+	} `toml:"project"`
+	DependencyGroups map[string][]interface{} `toml:"dependency-groups"`
+}
+
+// parsePyproject reads the dependencies a project declares in the PEP 621 [project] table, its optional
+// dependencies and its PEP 735 dependency groups. Only requirements pinning a single version have a package version.
+func parsePyproject(_ context.Context, _ string, reader io.Reader) ([]*pkg.Package, []artifact.Relationship, error) {
+	tree, err := toml.LoadReader(reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to load pyproject.toml for parsing: %v", err)
+	}
+
+	var project pyprojectToml
+	if err := tree.Unmarshal(&project); err != nil {
+		return nil, nil, fmt.Errorf("unable to parse pyproject.toml: %v", err)
+	}
+
+	declared := append([]string(nil), project.Project.Dependencies...)
+	for _, group := range sortedKeys(project.Project.OptionalDependencies) {
+		declared = append(declared, project.Project.OptionalDependencies[group]...)
+	}
+	groups := make([]string, 0, len(project.DependencyGroups))
+	for group := range project.DependencyGroups {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+	for _, group := range groups {
+		for _, entry := range project.DependencyGroups[group] {
+			// groups may also include other groups by a table, which adds no requirement of its own
+			if s, ok := entry.(string); ok {
+				declared = append(declared, s)
+			}
+		}
+	}
+
+	var pkgs []*pkg.Package
+	seen := make(map[string]bool)
+	for _, s := range declared {
+		req, ok := parseRequirement(s)
+		if !ok {
+			continue
+		}
+		key := normalizeName(req.name) + " " + req.constraint + " " + req.markers
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		pkgs = append(pkgs, &pkg.Package{
+			Name:         req.name,
+			Version:      req.pinnedVersion(),
+			Language:     pkg.Python,
+			Type:         pkg.PythonPkg,
+			MetadataType: pkg.PythonRequirementsMetadataType,
+			Metadata: pkg.PythonRequirementsMetadata{
+				Name:              req.name,
+				Extras:            req.extras,
+				VersionConstraint: req.constraint,
+				Markers:           req.markers,
+			},
+		})
+	}
+
+	return pkgs, nil, nil
+}
+
+func sortedKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
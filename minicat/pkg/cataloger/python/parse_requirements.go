@@ -2,6 +2,7 @@ package python
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"io"
 	"strings"
@@ -13,7 +14,7 @@ import (
 
 var _ common.ParserFn = parseRequirementsTxt
 
-func parseRequirementsTxt(_ string, reader io.Reader) ([]*pkg.Package, []artifact.Relationship, error) {
+func parseRequirementsTxt(_ context.Context, _ string, reader io.Reader) ([]*pkg.Package, []artifact.Relationship, error) {
 	packages := make([]*pkg.Package, 0)
 
 	scanner := bufio.NewScanner(reader)
@@ -2,6 +2,7 @@ package python
 
 import (
 	"bufio"
+	"context"
 	"io"
 	"regexp"
 	"strings"
@@ -17,7 +18,7 @@ var _ common.ParserFn = parseSetup
 
 var pinnedDependency = regexp.MustCompile(`['"]\W?(\w+\W?==\W?[\w\.]*)`)
 
-func parseSetup(_ string, reader io.Reader) ([]*pkg.Package, []artifact.Relationship, error) {
+func parseSetup(_ context.Context, _ string, reader io.Reader) ([]*pkg.Package, []artifact.Relationship, error) {
 	packages := make([]*pkg.Package, 0)
 
 	scanner := bufio.NewScanner(reader)
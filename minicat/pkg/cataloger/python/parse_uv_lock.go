@@ -0,0 +1,216 @@
+package python
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/pelletier/go-toml"
+	"github.com/scylladb/go-set/strset"
+
+	"github.com/lovewebshell/minicat/minicat/artifact"
+	"github.com/lovewebshell/minicat/minicat/pkg"
+	"github.com/lovewebshell/minicat/minicat/pkg/cataloger/common"
+)
+
+var _ common.ParserFn = parseUvLock
+
+type uvLock struct {
+	Packages []uvLockPackage `toml:"package"`
+}
+
+type uvLockPackage struct {
+	Name    string `toml:"name"`
+	Version string `toml:"version"`
+	Source  struct {
+		Registry  string `toml:"registry"`
+		Git       string `toml:"git"`
+		URL       string `toml:"url"`
+		Path      string `toml:"path"`
+		Directory string `toml:"directory"`
+		Editable  string `toml:"editable"`
+		Virtual   string `toml:"virtual"`
+	} `toml:"source"`
+	Dependencies         []uvLockDependency            `toml:"dependencies"`
+	OptionalDependencies map[string][]uvLockDependency `toml:"optional-dependencies"`
+	DevDependencies      map[string][]uvLockDependency `toml:"dev-dependencies"`
+	Sdist                *uvLockArtifact               `toml:"sdist"`
+	Wheels               []uvLockArtifact              `toml:"wheels"`
+}
+
+type uvLockDependency struct {
+	Name    string   `toml:"name"`
+	Version string   `toml:"version"`
+	Marker  string   `toml:"marker"`
+	Extra   []string `toml:"extra"`
+}
+
+type uvLockArtifact struct {
+	Hash string `toml:"hash"`
+}
+
+// parseUvLock reads the packages resolved by uv. The project itself and other local projects (workspace members and
+// path dependencies) are sources of dependencies only. Every package records the markers under which it is required
+// (an optional dependency being required under the extra of its group) and the extras of it that are requested.
+func parseUvLock(_ context.Context, _ string, reader io.Reader) ([]*pkg.Package, []artifact.Relationship, error) {
+	tree, err := toml.LoadReader(reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to load uv.lock for parsing: %v", err)
+	}
+
+	var lock uvLock
+	if err := tree.Unmarshal(&lock); err != nil {
+		return nil, nil, fmt.Errorf("unable to parse uv.lock: %v", err)
+	}
+
+	var pkgs []*pkg.Package
+	pkgsByEntry := make(map[int]*pkg.Package)
+	pkgsByName := make(map[string][]*pkg.Package)
+	for i, entry := range lock.Packages {
+		source := entry.Source
+		if source.Editable != "" || source.Virtual != "" || source.Directory != "" {
+			continue
+		}
+
+		var hashes []string
+		if entry.Sdist != nil && entry.Sdist.Hash != "" {
+			hashes = append(hashes, entry.Sdist.Hash)
+		}
+		for _, wheel := range entry.Wheels {
+			if wheel.Hash != "" {
+				hashes = append(hashes, wheel.Hash)
+			}
+		}
+
+		origin := source.Registry
+		for _, s := range []string{source.Git, source.URL, source.Path} {
+			if origin == "" {
+				origin = s
+			}
+		}
+
+		p := &pkg.Package{
+			Name:         entry.Name,
+			Version:      entry.Version,
+			Language:     pkg.Python,
+			Type:         pkg.PythonPkg,
+			MetadataType: pkg.PythonUvLockMetadataType,
+			Metadata: pkg.PythonUvLockMetadata{
+				Source: origin,
+				Hashes: hashes,
+			},
+		}
+		pkgs = append(pkgs, p)
+		pkgsByEntry[i] = p
+		pkgsByName[normalizeName(entry.Name)] = append(pkgsByName[normalizeName(entry.Name)], p)
+	}
+
+	// dependencies only name the version when the lock holds more than one version of the package
+	resolve := func(dep uvLockDependency) *pkg.Package {
+		for _, p := range pkgsByName[normalizeName(dep.Name)] {
+			if dep.Version == "" || dep.Version == p.Version {
+				return p
+			}
+		}
+		return nil
+	}
+
+	markers := make(map[*pkg.Package]*strset.Set)
+	extras := make(map[*pkg.Package]*strset.Set)
+	require := func(deps []uvLockDependency, group string) {
+		for _, d := range deps {
+			dep := resolve(d)
+			if dep == nil {
+				continue
+			}
+			if marker := uvLockMarker(d.Marker, group); marker != "" {
+				if markers[dep] == nil {
+					markers[dep] = strset.New()
+				}
+				markers[dep].Add(marker)
+			}
+			if len(d.Extra) > 0 {
+				if extras[dep] == nil {
+					extras[dep] = strset.New()
+				}
+				extras[dep].Add(d.Extra...)
+			}
+		}
+	}
+	for _, entry := range lock.Packages {
+		require(entry.Dependencies, "")
+		for group, deps := range entry.OptionalDependencies {
+			require(deps, group)
+		}
+		for _, deps := range entry.DevDependencies {
+			require(deps, "")
+		}
+	}
+	for _, p := range pkgs {
+		metadata := p.Metadata.(pkg.PythonUvLockMetadata)
+		if set, ok := markers[p]; ok {
+			metadata.Markers = set.List()
+			sort.Strings(metadata.Markers)
+		}
+		if set, ok := extras[p]; ok {
+			metadata.Extras = set.List()
+			sort.Strings(metadata.Extras)
+		}
+		p.Metadata = metadata
+	}
+
+	var relationships []artifact.Relationship
+	seen := make(map[[2]*pkg.Package]bool)
+	relate := func(p *pkg.Package, deps []uvLockDependency, ty artifact.RelationshipType) {
+		for _, d := range deps {
+			dep := resolve(d)
+			if dep == nil || dep == p || seen[[2]*pkg.Package{dep, p}] {
+				continue
+			}
+			seen[[2]*pkg.Package{dep, p}] = true
+			relationships = append(relationships, artifact.Relationship{
+				From: dep,
+				To:   p,
+				Type: ty,
+			})
+		}
+	}
+
+	for i, entry := range lock.Packages {
+		p, ok := pkgsByEntry[i]
+		if !ok {
+			continue
+		}
+		relate(p, entry.Dependencies, artifact.RuntimeDependencyOfRelationship)
+		for _, group := range sortedUvLockGroups(entry.OptionalDependencies) {
+			relate(p, entry.OptionalDependencies[group], artifact.RuntimeDependencyOfRelationship)
+		}
+		for _, group := range sortedUvLockGroups(entry.DevDependencies) {
+			relate(p, entry.DevDependencies[group], artifact.DevDependencyOfRelationship)
+		}
+	}
+
+	return pkgs, relationships, nil
+}
+
+// uvLockMarker is the marker of a dependency, restricted to the extra of the optional dependency group declaring it.
+func uvLockMarker(marker, group string) string {
+	if group == "" {
+		return marker
+	}
+	extra := fmt.Sprintf("extra == '%s'", group)
+	if marker == "" {
+		return extra
+	}
+	return fmt.Sprintf("(%s) and %s", marker, extra)
+}
+
+func sortedUvLockGroups(groups map[string][]uvLockDependency) []string {
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
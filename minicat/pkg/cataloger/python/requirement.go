@@ -0,0 +1,69 @@
+package python
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	requirementExp = regexp.MustCompile(`^\s*([A-Za-z0-9][A-Za-z0-9._-]*)\s*(?:\[([^\]]*)\])?\s*(.*)$`)
+
+	nameSeparatorExp = regexp.MustCompile(`[-_.]+`)
+)
+
+// requirement is a PEP 508 dependency specification such as `requests[socks]>=2.8.1,<3; python_version < "3.8"`.
+type requirement struct {
+	name       string
+	extras     []string
+	constraint string
+	markers    string
+}
+
+func parseRequirement(s string) (requirement, bool) {
+	s, markers := s, ""
+	if parts := strings.SplitN(s, ";", 2); len(parts) == 2 {
+		s, markers = parts[0], strings.TrimSpace(parts[1])
+	}
+
+	matches := requirementExp.FindStringSubmatch(s)
+	if matches == nil {
+		return requirement{}, false
+	}
+
+	var extras []string
+	for _, extra := range strings.Split(matches[2], ",") {
+		if extra = strings.TrimSpace(extra); extra != "" {
+			extras = append(extras, extra)
+		}
+	}
+
+	return requirement{
+		name:       matches[1],
+		extras:     extras,
+		constraint: strings.TrimSpace(strings.Trim(strings.TrimSpace(matches[3]), "()")),
+		markers:    markers,
+	}, true
+}
+
+// pinnedVersion is the version a requirement allows exclusively, if any.
+func (r requirement) pinnedVersion() string {
+	if strings.Contains(r.constraint, ",") {
+		return ""
+	}
+	for _, op := range []string{"===", "=="} {
+		if strings.HasPrefix(r.constraint, op) {
+			version := strings.TrimSpace(strings.TrimPrefix(r.constraint, op))
+			if strings.Contains(version, "*") {
+				return ""
+			}
+			return version
+		}
+	}
+	return ""
+}
+
+// normalizeName is the PEP 503 normalized form of a project name, which is how dependencies refer to packages
+// regardless of the spelling used by either.
+func normalizeName(name string) string {
+	return strings.ToLower(nameSeparatorExp.ReplaceAllString(name, "-"))
+}
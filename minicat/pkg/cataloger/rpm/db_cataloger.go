@@ -5,6 +5,7 @@ and a FileCataloger for RPM files.
 package rpm
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/lovewebshell/minicat/internal"
@@ -25,14 +26,17 @@ func (c *DBCataloger) Name() string {
 	return dbCatalogerName
 }
 
-func (c *DBCataloger) Catalog(resolver source.FileResolver) ([]pkg.Package, []artifact.Relationship, error) {
-	fileMatches, err := resolver.FilesByGlob(pkg.RpmDBGlob)
+func (c *DBCataloger) Catalog(ctx context.Context, resolver source.FileResolver) ([]pkg.Package, []artifact.Relationship, error) {
+	fileMatches, err := resolver.FilesByGlob(ctx, pkg.RpmDBGlob)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to find rpmdb's by glob: %w", err)
 	}
 
 	var pkgs []pkg.Package
 	for _, location := range fileMatches {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
 		dbContentReader, err := resolver.FileContentsByLocation(location)
 		if err != nil {
 			return nil, nil, err
@@ -47,12 +51,15 @@ func (c *DBCataloger) Catalog(resolver source.FileResolver) ([]pkg.Package, []ar
 		pkgs = append(pkgs, discoveredPkgs...)
 	}
 
-	manifestFileMatches, err := resolver.FilesByGlob(pkg.RpmManifestGlob)
+	manifestFileMatches, err := resolver.FilesByGlob(ctx, pkg.RpmManifestGlob)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to find rpm manifests by glob: %w", err)
 	}
 
 	for _, location := range manifestFileMatches {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
 		reader, err := resolver.FileContentsByLocation(location)
 		if err != nil {
 			return nil, nil, err
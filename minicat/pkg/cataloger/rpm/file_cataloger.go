@@ -1,6 +1,7 @@
 package rpm
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
@@ -25,14 +26,17 @@ func (c *FileCataloger) Name() string {
 	return "rpm-file-cataloger"
 }
 
-func (c *FileCataloger) Catalog(resolver source.FileResolver) ([]pkg.Package, []artifact.Relationship, error) {
-	fileMatches, err := resolver.FilesByGlob("**/*.rpm")
+func (c *FileCataloger) Catalog(ctx context.Context, resolver source.FileResolver) ([]pkg.Package, []artifact.Relationship, error) {
+	fileMatches, err := resolver.FilesByGlob(ctx, "**/*.rpm")
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to find rpm files's by glob: %w", err)
 	}
 
 	var pkgs []pkg.Package
 	for _, location := range fileMatches {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
 		contentReader, err := resolver.FileContentsByLocation(location)
 		if err != nil {
 			return nil, nil, err
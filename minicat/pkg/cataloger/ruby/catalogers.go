@@ -0,0 +1,24 @@
+/*
+Package ruby provides concrete Cataloger implementations for Ruby ecosystem files (Gemfile.lock and gemspecs).
+*/
+package ruby
+
+import (
+	"github.com/lovewebshell/minicat/minicat/pkg/cataloger/common"
+)
+
+func NewGemFileLockCataloger() *common.GenericCataloger {
+	globParsers := map[string]common.ParserFn{
+		"**/Gemfile.lock": parseGemFileLockEntries,
+	}
+
+	return common.NewGenericCataloger(nil, globParsers, "ruby-gemfile-cataloger")
+}
+
+func NewGemSpecCataloger() *common.GenericCataloger {
+	globParsers := map[string]common.ParserFn{
+		"**/specifications/**/*.gemspec": parseGemSpecEntries,
+	}
+
+	return common.NewGenericCataloger(nil, globParsers, "ruby-gemspec-cataloger")
+}
@@ -0,0 +1,56 @@
+package ruby
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"strings"
+
+	"github.com/lovewebshell/minicat/internal"
+	"github.com/lovewebshell/minicat/minicat/artifact"
+	"github.com/lovewebshell/minicat/minicat/pkg"
+)
+
+var sectionsOfInterest = internal.NewStringSet("GEM", "GIT", "PATH", "PLUGIN SOURCE")
+
+func parseGemFileLockEntries(_ context.Context, _ string, reader io.Reader) ([]*pkg.Package, []artifact.Relationship, error) {
+	var pkgs []*pkg.Package
+	scanner := bufio.NewScanner(reader)
+
+	var currentSection string
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		sanitizedLine := strings.TrimSpace(line)
+
+		if len(line) > 1 && line[0] != ' ' {
+			currentSection = sanitizedLine
+		} else if sectionsOfInterest.Contains(currentSection) {
+			if isDependencyLine(line) {
+				candidate := strings.Fields(sanitizedLine)
+				if len(candidate) != 2 {
+					continue
+				}
+				pkgs = append(pkgs, &pkg.Package{
+					Name:     candidate[0],
+					Version:  strings.Trim(candidate[1], "()"),
+					Language: pkg.Ruby,
+					Type:     pkg.GemPkg,
+				})
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, err
+	}
+	return pkgs, nil, nil
+}
+
+// isDependencyLine reports whether the line is a resolved gem specification, which is indented by exactly four spaces
+// (the dependencies of each specification are indented by six).
+func isDependencyLine(line string) bool {
+	if len(line) < 5 {
+		return false
+	}
+	return strings.Count(line[:5], " ") == 4
+}
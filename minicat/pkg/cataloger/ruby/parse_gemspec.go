@@ -0,0 +1,132 @@
+package ruby
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+
+	"github.com/lovewebshell/minicat/internal"
+	"github.com/lovewebshell/minicat/minicat/artifact"
+	"github.com/lovewebshell/minicat/minicat/pkg"
+)
+
+type postProcessor func(string) []string
+
+var patterns = map[string]*regexp.Regexp{
+	"name":     regexp.MustCompile(`.*\.name\s*=\s*["']{1}(?P<name>.*)["']{1} *`),
+	"version":  regexp.MustCompile(`.*\.version\s*=\s*["']{1}(?P<version>.*)["']{1} *`),
+	"homepage": regexp.MustCompile(`.*\.homepage\s*=\s*["']{1}(?P<homepage>.*)["']{1} *`),
+	"files":    regexp.MustCompile(`.*\.files\s*=\s*\[(?P<files>.*)\] *`),
+	"authors":  regexp.MustCompile(`.*\.authors\s*=\s*\[(?P<authors>.*)\] *`),
+	"license":  regexp.MustCompile(`.*\.license\s*=\s*["']{1}(?P<license>.*)["']{1} *`),
+	"licenses": regexp.MustCompile(`.*\.licenses\s*=\s*\[(?P<licenses>.*)\] *`),
+}
+
+var postProcessors = map[string]postProcessor{
+	"license":  processList,
+	"files":    processList,
+	"authors":  processList,
+	"licenses": processList,
+}
+
+func processList(s string) []string {
+	var results []string
+	for _, item := range strings.Split(s, ",") {
+		if item = unquote(item); item != "" {
+			results = append(results, item)
+		}
+	}
+	return results
+}
+
+// unquote strips the surrounding whitespace, quotes and any trailing ".freeze" from a ruby string literal.
+func unquote(s string) string {
+	s = strings.TrimSpace(s)
+	s = strings.TrimSuffix(s, ".freeze")
+	return strings.Trim(s, `"'`)
+}
+
+// parseGemSpecEntries reads an installed gemspec, whose files (relative to the directory the gem is installed to) are
+// recorded by their path.
+func parseGemSpecEntries(_ context.Context, realPath string, reader io.Reader) ([]*pkg.Package, []artifact.Relationship, error) {
+	var pkgs []*pkg.Package
+	var fields = make(map[string]interface{})
+	scanner := bufio.NewScanner(reader)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		sanitizedLine := strings.TrimSpace(line)
+		sanitizedLine = strings.ReplaceAll(sanitizedLine, "%q{", "")
+		sanitizedLine = strings.ReplaceAll(sanitizedLine, "}", "")
+
+		if sanitizedLine == "" {
+			continue
+		}
+
+		for field, pattern := range patterns {
+			matchMap := internal.MatchNamedCaptureGroups(pattern, sanitizedLine)
+			value := matchMap[field]
+			if value == "" {
+				continue
+			}
+
+			processor, ok := postProcessors[field]
+			switch {
+			case !ok:
+				fields[field] = unquote(value)
+			case field == "license":
+				fields["licenses"] = processor(value)
+			default:
+				fields[field] = processor(value)
+			}
+			break
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	if fields["name"] != nil && fields["version"] != nil {
+		var metadata pkg.GemMetadata
+		if err := mapstructure.Decode(fields, &metadata); err != nil {
+			return nil, nil, fmt.Errorf("unable to decode gem metadata: %w", err)
+		}
+		if dir := gemInstallDir(realPath); dir != "" {
+			for i, f := range metadata.Files {
+				if !path.IsAbs(f) {
+					metadata.Files[i] = path.Join(dir, f)
+				}
+			}
+		}
+
+		pkgs = append(pkgs, &pkg.Package{
+			Name:         metadata.Name,
+			Version:      metadata.Version,
+			Licenses:     metadata.Licenses,
+			Language:     pkg.Ruby,
+			Type:         pkg.GemPkg,
+			MetadataType: pkg.GemMetadataType,
+			Metadata:     metadata,
+		})
+	}
+
+	return pkgs, nil, nil
+}
+
+// gemInstallDir is the directory a gem is installed to, next to the specifications directory holding its gemspec:
+// <gem home>/specifications/[default/]<gem>.gemspec is installed to <gem home>/gems/<gem>.
+func gemInstallDir(realPath string) string {
+	i := strings.LastIndex(realPath, "/specifications/")
+	if i < 0 {
+		return ""
+	}
+	return path.Join(realPath[:i], "gems", strings.TrimSuffix(path.Base(realPath), ".gemspec"))
+}
@@ -0,0 +1,124 @@
+package rust
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	rustaudit "github.com/microsoft/go-rustaudit"
+
+	"github.com/lovewebshell/minicat/internal"
+	"github.com/lovewebshell/minicat/internal/log"
+	"github.com/lovewebshell/minicat/minicat/artifact"
+	"github.com/lovewebshell/minicat/minicat/pkg"
+	"github.com/lovewebshell/minicat/minicat/source"
+)
+
+const auditBinaryCatalogerName = "cargo-auditable-binary-cataloger"
+
+type AuditBinaryCataloger struct{}
+
+func NewAuditBinaryCataloger() *AuditBinaryCataloger {
+	return &AuditBinaryCataloger{}
+}
+
+func (c *AuditBinaryCataloger) Name() string {
+	return auditBinaryCatalogerName
+}
+
+func (c *AuditBinaryCataloger) Catalog(ctx context.Context, resolver source.FileResolver) ([]pkg.Package, []artifact.Relationship, error) {
+	fileMatches, err := resolver.FilesByMIMEType(internal.ExecutableMIMETypeSet.List()...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find executables by mime type: %w", err)
+	}
+
+	var pkgs []pkg.Package
+	var relationships []artifact.Relationship
+	for _, location := range fileMatches {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+		reader, err := resolver.FileContentsByLocation(location)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		info, err := scanAuditBinary(reader)
+		internal.CloseAndLogError(reader, location.VirtualPath)
+		if err != nil {
+			log.Debugf("rust audit binary cataloger: unable to read dependency info from file=%q: %+v", location.RealPath, err)
+			continue
+		}
+
+		discoveredPkgs, discoveredRelationships := auditPackages(info, location)
+		pkgs = append(pkgs, discoveredPkgs...)
+		relationships = append(relationships, discoveredRelationships...)
+	}
+
+	return pkgs, relationships, nil
+}
+
+// scanAuditBinary reads the dependency information embedded by cargo-auditable. Executables that were not built with
+// cargo-auditable yield an empty result and no error.
+func scanAuditBinary(reader io.Reader) (rustaudit.VersionInfo, error) {
+	r, ok := reader.(io.ReaderAt)
+	if !ok {
+		contents, err := io.ReadAll(reader)
+		if err != nil {
+			return rustaudit.VersionInfo{}, fmt.Errorf("unable to read binary: %w", err)
+		}
+		r = bytes.NewReader(contents)
+	}
+
+	info, err := rustaudit.GetDependencyInfo(r)
+	if errors.Is(err, rustaudit.ErrNoRustDepInfo) || errors.Is(err, rustaudit.ErrUnknownFileFormat) {
+		return rustaudit.VersionInfo{}, nil
+	}
+	return info, err
+}
+
+// auditPackages expresses every runtime crate linked into the binary as a package; build-time only crates (such as
+// proc-macros and build script dependencies) are not part of the binary and are skipped.
+func auditPackages(info rustaudit.VersionInfo, location source.Location) ([]pkg.Package, []artifact.Relationship) {
+	pkgsByIndex := make(map[int]*pkg.Package)
+	var pkgs []pkg.Package
+	for i, dep := range info.Packages {
+		if dep.Kind != rustaudit.Runtime || dep.Name == "" {
+			continue
+		}
+
+		p := newCargoPackage(pkg.CargoPackageMetadata{
+			Name:    dep.Name,
+			Version: dep.Version,
+			Source:  dep.Source,
+		})
+		p.FoundBy = auditBinaryCatalogerName
+		p.Locations = source.NewLocationSet(location)
+		p.SetID()
+		pkgsByIndex[i] = p
+		pkgs = append(pkgs, *p)
+	}
+
+	var relationships []artifact.Relationship
+	for i, dep := range info.Packages {
+		p, ok := pkgsByIndex[i]
+		if !ok {
+			continue
+		}
+		for _, d := range dep.Dependencies {
+			child, ok := pkgsByIndex[int(d)]
+			if !ok {
+				continue
+			}
+			relationships = append(relationships, artifact.Relationship{
+				From: *child,
+				To:   *p,
+				Type: artifact.DependencyOfRelationship,
+			})
+		}
+	}
+
+	return pkgs, relationships
+}
@@ -0,0 +1,17 @@
+/*
+Package rust provides concrete Cataloger implementations for the Rust ecosystem: Cargo.lock files and the dependency
+information embedded into binaries by cargo-auditable.
+*/
+package rust
+
+import (
+	"github.com/lovewebshell/minicat/minicat/pkg/cataloger/common"
+)
+
+func NewCargoLockCataloger() *common.GenericCataloger {
+	globParsers := map[string]common.ParserFn{
+		"**/Cargo.lock": parseCargoLock,
+	}
+
+	return common.NewGenericCataloger(nil, globParsers, "rust-cargo-lock-cataloger")
+}
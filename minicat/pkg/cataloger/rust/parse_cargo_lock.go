@@ -0,0 +1,106 @@
+package rust
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pelletier/go-toml"
+
+	"github.com/lovewebshell/minicat/minicat/artifact"
+	"github.com/lovewebshell/minicat/minicat/pkg"
+)
+
+type cargoLockFile struct {
+	Packages []pkg.CargoPackageMetadata `toml:"package"`
+}
+
+func parseCargoLock(_ context.Context, _ string, reader io.Reader) ([]*pkg.Package, []artifact.Relationship, error) {
+	tree, err := toml.LoadReader(reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to load Cargo.lock for parsing: %w", err)
+	}
+
+	var lockFile cargoLockFile
+	if err := tree.Unmarshal(&lockFile); err != nil {
+		return nil, nil, fmt.Errorf("unable to parse Cargo.lock: %w", err)
+	}
+
+	var pkgs []*pkg.Package
+	for _, m := range lockFile.Packages {
+		if m.Name == "" {
+			continue
+		}
+		pkgs = append(pkgs, newCargoPackage(m))
+	}
+
+	return pkgs, cargoLockRelationships(pkgs), nil
+}
+
+func newCargoPackage(m pkg.CargoPackageMetadata) *pkg.Package {
+	return &pkg.Package{
+		Name:         m.Name,
+		Version:      m.Version,
+		Language:     pkg.Rust,
+		Type:         pkg.RustPkg,
+		MetadataType: pkg.RustCargoPackageMetadataType,
+		Metadata:     m,
+	}
+}
+
+// cargoLockRelationships relates every package to the packages depending on it. Cargo only qualifies a dependency
+// with a version (and a source) when the lock file holds more than one package of that name, so a dependency may be
+// any of "name", "name version" or "name version (source)".
+func cargoLockRelationships(pkgs []*pkg.Package) []artifact.Relationship {
+	byName := make(map[string][]*pkg.Package)
+	for _, p := range pkgs {
+		byName[p.Name] = append(byName[p.Name], p)
+	}
+
+	var relationships []artifact.Relationship
+	for _, p := range pkgs {
+		m, ok := p.Metadata.(pkg.CargoPackageMetadata)
+		if !ok {
+			continue
+		}
+		for _, dependency := range m.Dependencies {
+			dep := findCargoDependency(byName, dependency)
+			if dep == nil {
+				continue
+			}
+			relationships = append(relationships, artifact.Relationship{
+				From: dep,
+				To:   p,
+				Type: artifact.DependencyOfRelationship,
+			})
+		}
+	}
+	return relationships
+}
+
+func findCargoDependency(byName map[string][]*pkg.Package, dependency string) *pkg.Package {
+	fields := strings.SplitN(strings.TrimSpace(dependency), " ", 3)
+	candidates := byName[fields[0]]
+	if len(fields) == 1 {
+		if len(candidates) == 1 {
+			return candidates[0]
+		}
+		return nil
+	}
+
+	var source string
+	if len(fields) == 3 {
+		source = strings.TrimSuffix(strings.TrimPrefix(fields[2], "("), ")")
+	}
+	for _, c := range candidates {
+		m, ok := c.Metadata.(pkg.CargoPackageMetadata)
+		if !ok || c.Version != fields[1] {
+			continue
+		}
+		if source == "" || m.Source == source {
+			return c
+		}
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+/*
+Package swift provides concrete Cataloger implementations for Swift/Objective-C ecosystem files (CocoaPods Podfile.lock
+and Swift Package Manager Package.resolved).
+*/
+package swift
+
+import (
+	"github.com/lovewebshell/minicat/minicat/pkg/cataloger/common"
+)
+
+func NewCocoapodsCataloger() *common.GenericCataloger {
+	globParsers := map[string]common.ParserFn{
+		"**/Podfile.lock": parsePodfileLock,
+	}
+
+	return common.NewGenericCataloger(nil, globParsers, "cocoapods-cataloger")
+}
+
+func NewSwiftPackageManagerCataloger() *common.GenericCataloger {
+	globParsers := map[string]common.ParserFn{
+		"**/Package.resolved":  parsePackageResolved,
+		"**/.package.resolved": parsePackageResolved,
+	}
+
+	return common.NewGenericCataloger(nil, globParsers, "swift-package-manager-cataloger")
+}
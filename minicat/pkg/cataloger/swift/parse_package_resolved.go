@@ -0,0 +1,89 @@
+package swift
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/lovewebshell/minicat/minicat/artifact"
+	"github.com/lovewebshell/minicat/minicat/pkg"
+)
+
+// packageResolved is a Swift Package Manager Package.resolved file. Version 1 files nest the pins in an object and
+// name them by package, while version 2 (and later) files list them at the top level, named by identity.
+type packageResolved struct {
+	Version int                     `json:"version"`
+	Object  packageResolvedObject   `json:"object"`
+	Pins    []packageResolvedPinsV2 `json:"pins"`
+}
+
+type packageResolvedObject struct {
+	Pins []packageResolvedPinsV1 `json:"pins"`
+}
+
+type packageResolvedPinsV1 struct {
+	Package       string               `json:"package"`
+	RepositoryURL string               `json:"repositoryURL"`
+	State         packageResolvedState `json:"state"`
+}
+
+type packageResolvedPinsV2 struct {
+	Identity string               `json:"identity"`
+	Kind     string               `json:"kind"`
+	Location string               `json:"location"`
+	State    packageResolvedState `json:"state"`
+}
+
+type packageResolvedState struct {
+	Branch   string `json:"branch"`
+	Revision string `json:"revision"`
+	Version  string `json:"version"`
+}
+
+func parsePackageResolved(_ context.Context, _ string, reader io.Reader) ([]*pkg.Package, []artifact.Relationship, error) {
+	var resolved packageResolved
+	if err := json.NewDecoder(reader).Decode(&resolved); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse Package.resolved file: %w", err)
+	}
+
+	var pkgs []*pkg.Package
+	switch resolved.Version {
+	case 1:
+		for _, pin := range resolved.Object.Pins {
+			pkgs = appendSwiftPackage(pkgs, pin.Package, pin.RepositoryURL, pin.State)
+		}
+	default:
+		for _, pin := range resolved.Pins {
+			pkgs = appendSwiftPackage(pkgs, pin.Identity, pin.Location, pin.State)
+		}
+	}
+
+	return pkgs, nil, nil
+}
+
+func appendSwiftPackage(pkgs []*pkg.Package, name, repositoryURL string, state packageResolvedState) []*pkg.Package {
+	// packages pinned to a branch have no version, only the revision the branch was at
+	version := state.Version
+	if version == "" {
+		version = state.Revision
+	}
+	if name == "" || version == "" {
+		return pkgs
+	}
+
+	return append(pkgs, &pkg.Package{
+		Name:         name,
+		Version:      version,
+		Language:     pkg.Swift,
+		Type:         pkg.SwiftPkg,
+		MetadataType: pkg.SwiftPackageManagerMetadataType,
+		Metadata: pkg.SwiftPackageManagerMetadata{
+			Name:          name,
+			Version:       version,
+			RepositoryURL: repositoryURL,
+			Revision:      state.Revision,
+			Branch:        state.Branch,
+		},
+	})
+}
@@ -0,0 +1,85 @@
+package swift
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/lovewebshell/minicat/minicat/artifact"
+	"github.com/lovewebshell/minicat/minicat/pkg"
+)
+
+type podfileLock struct {
+	Pods            []interface{}       `yaml:"PODS"`
+	Dependencies    []string            `yaml:"DEPENDENCIES"`
+	SpecRepos       map[string][]string `yaml:"SPEC REPOS"`
+	SpecChecksums   map[string]string   `yaml:"SPEC CHECKSUMS"`
+	PodfileChecksum string              `yaml:"PODFILE CHECKSUM"`
+	Cocoapods       string              `yaml:"COCOAPODS"`
+}
+
+func parsePodfileLock(_ context.Context, _ string, reader io.Reader) ([]*pkg.Package, []artifact.Relationship, error) {
+	var podfile podfileLock
+	if err := yaml.NewDecoder(reader).Decode(&podfile); err != nil {
+		return nil, nil, fmt.Errorf("unable to parse Podfile.lock: %w", err)
+	}
+
+	var pkgs []*pkg.Package
+	for _, podInterface := range podfile.Pods {
+		var podBlob string
+		switch v := podInterface.(type) {
+		case map[string]interface{}:
+			// pods with dependencies are expressed as a single key mapping to the dependency list
+			for k := range v {
+				podBlob = k
+			}
+		case string:
+			podBlob = v
+		default:
+			return nil, nil, fmt.Errorf("malformed Podfile.lock: unexpected pod entry %+v", podInterface)
+		}
+
+		name, version, ok := splitPodBlob(podBlob)
+		if !ok {
+			return nil, nil, fmt.Errorf("malformed Podfile.lock: unable to parse pod %q", podBlob)
+		}
+
+		// subspecs share the checksum of their root pod
+		rootName := strings.Split(name, "/")[0]
+		pkgHash, exists := podfile.SpecChecksums[rootName]
+		if !exists {
+			return nil, nil, fmt.Errorf("malformed Podfile.lock: incomplete checksums for pod %q", rootName)
+		}
+
+		pkgs = append(pkgs, &pkg.Package{
+			Name:         name,
+			Version:      version,
+			Language:     pkg.Swift,
+			Type:         pkg.PodPkg,
+			MetadataType: pkg.CocoapodsMetadataType,
+			Metadata: pkg.CocoapodsMetadata{
+				Name:    name,
+				Version: version,
+				PkgHash: pkgHash,
+			},
+		})
+	}
+
+	return pkgs, nil, nil
+}
+
+// splitPodBlob splits a pod entry of the form "Name/Subspec (1.2.3)" into its name and version.
+func splitPodBlob(podBlob string) (string, string, bool) {
+	fields := strings.SplitN(strings.TrimSpace(podBlob), " ", 2)
+	if len(fields) != 2 {
+		return "", "", false
+	}
+	version := strings.TrimSuffix(strings.TrimPrefix(fields[1], "("), ")")
+	if fields[0] == "" || version == "" {
+		return "", "", false
+	}
+	return fields[0], version, true
+}
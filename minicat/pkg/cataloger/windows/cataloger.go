@@ -0,0 +1,64 @@
+/*
+Package windows provides a concrete Cataloger implementation for the installed product and updates (KBs) of a Windows
+filesystem, read from the offline SOFTWARE registry hive or an exported kb-inventory.toml file.
+*/
+package windows
+
+import (
+	"strings"
+
+	"github.com/lovewebshell/minicat/minicat/artifact"
+	"github.com/lovewebshell/minicat/minicat/pkg"
+	"github.com/lovewebshell/minicat/minicat/pkg/cataloger/common"
+)
+
+func NewKbCataloger() *common.GenericCataloger {
+	globParsers := map[string]common.ParserFn{
+		"**/Windows/System32/config/SOFTWARE": parseSoftwareHive,
+		"**/kb-inventory.toml":                parseKbInventory,
+	}
+
+	return common.NewGenericCataloger(nil, globParsers, "windows-kb-cataloger")
+}
+
+func newProductPackage(name, productID, version string) *pkg.Package {
+	return &pkg.Package{
+		Name:         name,
+		Version:      version,
+		Type:         pkg.KbPkg,
+		MetadataType: pkg.KbPackageMetadataType,
+		Metadata: pkg.KbPackageMetadata{
+			ProductID: productID,
+		},
+	}
+}
+
+func newKbPackage(productID, kb, version string) *pkg.Package {
+	kb = strings.TrimPrefix(strings.ToUpper(kb), "KB")
+	return &pkg.Package{
+		Name:         "KB" + kb,
+		Version:      version,
+		Type:         pkg.KbPkg,
+		MetadataType: pkg.KbPackageMetadataType,
+		Metadata: pkg.KbPackageMetadata{
+			ProductID: productID,
+			Kb:        kb,
+		},
+	}
+}
+
+// productRelationships expresses every installed update as contained by the product it was installed on.
+func productRelationships(product *pkg.Package, kbs []*pkg.Package) []artifact.Relationship {
+	if product == nil {
+		return nil
+	}
+	var relationships []artifact.Relationship
+	for _, kb := range kbs {
+		relationships = append(relationships, artifact.Relationship{
+			From: product,
+			To:   kb,
+			Type: artifact.ContainsRelationship,
+		})
+	}
+	return relationships
+}
@@ -0,0 +1,66 @@
+package windows
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/pelletier/go-toml"
+
+	"github.com/lovewebshell/minicat/minicat/artifact"
+	"github.com/lovewebshell/minicat/minicat/pkg"
+)
+
+// kbInventory is the exported form of the installed product and updates, for example:
+//
+//	[product]
+//	name = "Windows Server 2019 Datacenter"
+//	product_id = "00430-00000-00000-AA000"
+//	version = "10.0.17763.3406"
+//
+//	[[kb]]
+//	product_id = "00430-00000-00000-AA000"
+//	kb = "5017315"
+type kbInventory struct {
+	Product kbInventoryProduct      `toml:"product"`
+	Kbs     []pkg.KbPackageMetadata `toml:"kb"`
+}
+
+type kbInventoryProduct struct {
+	Name      string `toml:"name"`
+	ProductID string `toml:"product_id"`
+	Version   string `toml:"version"`
+}
+
+func parseKbInventory(_ context.Context, _ string, reader io.Reader) ([]*pkg.Package, []artifact.Relationship, error) {
+	tree, err := toml.LoadReader(reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to load kb inventory for parsing: %w", err)
+	}
+
+	var inventory kbInventory
+	if err := tree.Unmarshal(&inventory); err != nil {
+		return nil, nil, fmt.Errorf("unable to parse kb inventory: %w", err)
+	}
+
+	var product *pkg.Package
+	var pkgs []*pkg.Package
+	if inventory.Product.Name != "" {
+		product = newProductPackage(inventory.Product.Name, inventory.Product.ProductID, inventory.Product.Version)
+		pkgs = append(pkgs, product)
+	}
+
+	var kbs []*pkg.Package
+	for _, m := range inventory.Kbs {
+		if m.Kb == "" {
+			continue
+		}
+		productID := m.ProductID
+		if productID == "" {
+			productID = inventory.Product.ProductID
+		}
+		kbs = append(kbs, newKbPackage(productID, m.Kb, ""))
+	}
+
+	return append(pkgs, kbs...), productRelationships(product, kbs), nil
+}
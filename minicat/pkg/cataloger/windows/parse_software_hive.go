@@ -0,0 +1,167 @@
+package windows
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/lovewebshell/minicat/internal/file"
+	"github.com/lovewebshell/minicat/internal/log"
+	"github.com/lovewebshell/minicat/minicat/artifact"
+	"github.com/lovewebshell/minicat/minicat/pkg"
+)
+
+const (
+	currentVersionKey    = `Microsoft\Windows NT\CurrentVersion`
+	hotFixKey            = `Microsoft\Windows NT\CurrentVersion\HotFix`
+	servicingPackagesKey = `Microsoft\Windows\CurrentVersion\Component Based Servicing\Packages`
+
+	// the CurrentState of a servicing package that has been installed
+	cbsStateInstalled = "112"
+
+	// maxSoftwareHiveSize bounds the hive read in full before parsing it, for every cataloger worker parsing one
+	// (SOFTWARE hives are usually well below it)
+	maxSoftwareHiveSize = 256 * file.MB
+)
+
+var kbPattern = regexp.MustCompile(`(?i)KB(\d+)`)
+
+func parseSoftwareHive(_ context.Context, path string, reader io.Reader) ([]*pkg.Package, []artifact.Relationship, error) {
+	contents, err := io.ReadAll(io.LimitReader(reader, maxSoftwareHiveSize+1))
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to read registry hive: %w", err)
+	}
+	if len(contents) > maxSoftwareHiveSize {
+		return nil, nil, fmt.Errorf("registry hive exceeds the limit of %d bytes", maxSoftwareHiveSize)
+	}
+
+	hive, err := newRegistryHive(contents)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to parse registry hive: %w", err)
+	}
+
+	root, err := hive.Root()
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to read registry hive root: %w", err)
+	}
+
+	var pkgs []*pkg.Package
+	product, productID := productFromHive(root)
+	if product != nil {
+		pkgs = append(pkgs, product)
+	}
+
+	versions := installedKbsFromHive(root, path)
+	kbNumbers := make([]string, 0, len(versions))
+	for kb := range versions {
+		kbNumbers = append(kbNumbers, kb)
+	}
+	sort.Strings(kbNumbers)
+
+	var kbs []*pkg.Package
+	for _, kb := range kbNumbers {
+		kbs = append(kbs, newKbPackage(productID, kb, versions[kb]))
+	}
+
+	return append(pkgs, kbs...), productRelationships(product, kbs), nil
+}
+
+func productFromHive(root *registryKey) (*pkg.Package, string) {
+	key, err := root.Subkey(currentVersionKey)
+	if err != nil {
+		return nil, ""
+	}
+
+	name := stringValue(key, "ProductName")
+	if name == "" {
+		return nil, ""
+	}
+	productID := stringValue(key, "ProductId")
+
+	return newProductPackage(name, productID, productVersion(key)), productID
+}
+
+// productVersion renders the full build version of the product (e.g. 10.0.17763.3406).
+func productVersion(key *registryKey) string {
+	build := stringValue(key, "CurrentBuildNumber")
+	if build == "" {
+		build = stringValue(key, "CurrentBuild")
+	}
+	if build == "" {
+		return stringValue(key, "CurrentVersion")
+	}
+
+	var fields []string
+	if major := stringValue(key, "CurrentMajorVersionNumber"); major != "" {
+		fields = append(fields, major, stringValue(key, "CurrentMinorVersionNumber"))
+	} else if current := stringValue(key, "CurrentVersion"); current != "" {
+		fields = append(fields, current)
+	}
+	fields = append(fields, build)
+	if ubr := stringValue(key, "UBR"); ubr != "" {
+		fields = append(fields, ubr)
+	}
+	return strings.Join(fields, ".")
+}
+
+// installedKbsFromHive returns the version of every installed KB, keyed by KB number, from the component based
+// servicing packages and the legacy HotFix key.
+func installedKbsFromHive(root *registryKey, path string) map[string]string {
+	kbs := make(map[string]string)
+
+	if key, err := root.Subkey(servicingPackagesKey); err == nil {
+		subkeys, err := key.Subkeys()
+		if err != nil {
+			log.Warnf("unable to read servicing packages from registry hive=%q: %+v", path, err)
+		}
+		for _, s := range subkeys {
+			match := kbPattern.FindStringSubmatch(s.Name())
+			if match == nil {
+				continue
+			}
+			if state := stringValue(s, "CurrentState"); state != "" && state != cbsStateInstalled {
+				continue
+			}
+			if kbs[match[1]] == "" {
+				kbs[match[1]] = servicingPackageVersion(s.Name())
+			}
+		}
+	}
+
+	if key, err := root.Subkey(hotFixKey); err == nil {
+		subkeys, err := key.Subkeys()
+		if err != nil {
+			log.Warnf("unable to read hotfixes from registry hive=%q: %+v", path, err)
+		}
+		for _, s := range subkeys {
+			if match := kbPattern.FindStringSubmatch(s.Name()); match != nil {
+				if _, exists := kbs[match[1]]; !exists {
+					kbs[match[1]] = ""
+				}
+			}
+		}
+	}
+
+	return kbs
+}
+
+// servicingPackageVersion extracts the version from a servicing package name of the form
+// "Package_for_KB5017315~31bf3856ad364e35~amd64~~17763.3406.1.5".
+func servicingPackageVersion(name string) string {
+	fields := strings.Split(name, "~")
+	if len(fields) < 5 {
+		return ""
+	}
+	return fields[4]
+}
+
+func stringValue(key *registryKey, name string) string {
+	v, ok := key.Value(name)
+	if !ok {
+		return ""
+	}
+	return v.String()
+}
@@ -0,0 +1,372 @@
+package windows
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"unicode/utf16"
+)
+
+const (
+	hiveBaseBlockSize = 4096
+
+	keyCompressedName   = 0x0020
+	valueCompressedName = 0x0001
+
+	valueDataInline  = 0x80000000
+	bigDataThreshold = 16344
+
+	// index roots only point to subkey lists in well-formed hives; deeper lists are taken for a corrupt hive
+	maxSubkeyListDepth = 2
+
+	regSZ       = 1
+	regExpandSZ = 2
+	regDWORD    = 4
+	regMultiSZ  = 7
+	regQWORD    = 11
+)
+
+var hiveSignature = []byte("regf")
+
+// registryHive is a minimal, read-only reader for offline registry hive files ("regf" format). Only the records needed
+// to walk the key tree and read string and integer values are supported.
+type registryHive struct {
+	data []byte
+	root uint32
+}
+
+type registryKey struct {
+	hive   *registryHive
+	offset uint32
+	name   string
+}
+
+type registryValue struct {
+	name     string
+	dataType uint32
+	data     []byte
+}
+
+func newRegistryHive(data []byte) (*registryHive, error) {
+	if len(data) < hiveBaseBlockSize || !bytes.Equal(data[:4], hiveSignature) {
+		return nil, fmt.Errorf("not a registry hive")
+	}
+	return &registryHive{
+		data: data,
+		root: binary.LittleEndian.Uint32(data[0x24:]),
+	}, nil
+}
+
+// cell returns the data of the cell at the given offset (relative to the first hive bin).
+func (h *registryHive) cell(offset uint32) ([]byte, error) {
+	start := hiveBaseBlockSize + int(offset)
+	if start+4 > len(h.data) || start < hiveBaseBlockSize {
+		return nil, fmt.Errorf("cell offset out of range: %#x", offset)
+	}
+	size := int32(binary.LittleEndian.Uint32(h.data[start:]))
+	if size < 0 {
+		size = -size
+	}
+	end := start + int(size)
+	if size < 4 || end > len(h.data) {
+		return nil, fmt.Errorf("invalid cell size at offset %#x", offset)
+	}
+	return h.data[start+4 : end], nil
+}
+
+func (h *registryHive) Root() (*registryKey, error) {
+	return h.key(h.root)
+}
+
+func (h *registryHive) key(offset uint32) (*registryKey, error) {
+	c, err := h.cell(offset)
+	if err != nil {
+		return nil, err
+	}
+	if len(c) < 0x4C || string(c[:2]) != "nk" {
+		return nil, fmt.Errorf("invalid key record at offset %#x", offset)
+	}
+	flags := binary.LittleEndian.Uint16(c[0x02:])
+	nameLength := int(binary.LittleEndian.Uint16(c[0x48:]))
+	if 0x4C+nameLength > len(c) {
+		return nil, fmt.Errorf("invalid key name at offset %#x", offset)
+	}
+	return &registryKey{
+		hive:   h,
+		offset: offset,
+		name:   decodeName(c[0x4C:0x4C+nameLength], flags&keyCompressedName != 0),
+	}, nil
+}
+
+func (k *registryKey) Name() string {
+	return k.name
+}
+
+// Subkey walks the given backslash separated path (case insensitive) from this key.
+func (k *registryKey) Subkey(path string) (*registryKey, error) {
+	current := k
+	for _, name := range strings.Split(strings.Trim(path, `\`), `\`) {
+		subkeys, err := current.Subkeys()
+		if err != nil {
+			return nil, err
+		}
+		var next *registryKey
+		for _, s := range subkeys {
+			if strings.EqualFold(s.name, name) {
+				next = s
+				break
+			}
+		}
+		if next == nil {
+			return nil, fmt.Errorf("registry key not found: %q", path)
+		}
+		current = next
+	}
+	return current, nil
+}
+
+func (k *registryKey) Subkeys() ([]*registryKey, error) {
+	c, err := k.hive.cell(k.offset)
+	if err != nil {
+		return nil, err
+	}
+	if binary.LittleEndian.Uint32(c[0x14:]) == 0 {
+		return nil, nil
+	}
+
+	offsets, err := k.hive.subkeyOffsets(binary.LittleEndian.Uint32(c[0x1C:]))
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []*registryKey
+	for _, offset := range offsets {
+		key, err := k.hive.key(offset)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func (h *registryHive) subkeyOffsets(listOffset uint32) ([]uint32, error) {
+	return h.subkeyListOffsets(listOffset, 0, make(map[uint32]bool))
+}
+
+// subkeyListOffsets reads the subkey list at the given offset, following index roots to the lists they point to. The
+// depth and the lists already read bound the walk of a corrupt (or crafted) hive.
+func (h *registryHive) subkeyListOffsets(listOffset uint32, depth int, visited map[uint32]bool) ([]uint32, error) {
+	if depth >= maxSubkeyListDepth {
+		return nil, fmt.Errorf("subkey lists nested too deep at offset %#x", listOffset)
+	}
+	if visited[listOffset] {
+		return nil, fmt.Errorf("subkey list at offset %#x is referenced more than once", listOffset)
+	}
+	visited[listOffset] = true
+
+	c, err := h.cell(listOffset)
+	if err != nil {
+		return nil, err
+	}
+	if len(c) < 4 {
+		return nil, fmt.Errorf("invalid subkey list at offset %#x", listOffset)
+	}
+
+	count := int(binary.LittleEndian.Uint16(c[2:]))
+	var stride int
+	switch string(c[:2]) {
+	case "lf", "lh":
+		stride = 8
+	case "li", "ri":
+		stride = 4
+	default:
+		return nil, fmt.Errorf("unknown subkey list type %q at offset %#x", string(c[:2]), listOffset)
+	}
+	if 4+count*stride > len(c) {
+		return nil, fmt.Errorf("truncated subkey list at offset %#x", listOffset)
+	}
+
+	var offsets []uint32
+	for i := 0; i < count; i++ {
+		offset := binary.LittleEndian.Uint32(c[4+i*stride:])
+		if string(c[:2]) != "ri" {
+			offsets = append(offsets, offset)
+			continue
+		}
+		// index roots point to further subkey lists
+		nested, err := h.subkeyListOffsets(offset, depth+1, visited)
+		if err != nil {
+			return nil, err
+		}
+		offsets = append(offsets, nested...)
+	}
+	return offsets, nil
+}
+
+func (k *registryKey) Values() ([]registryValue, error) {
+	c, err := k.hive.cell(k.offset)
+	if err != nil {
+		return nil, err
+	}
+	count := int(binary.LittleEndian.Uint32(c[0x24:]))
+	if count == 0 {
+		return nil, nil
+	}
+
+	list, err := k.hive.cell(binary.LittleEndian.Uint32(c[0x28:]))
+	if err != nil {
+		return nil, err
+	}
+	if count*4 > len(list) {
+		return nil, fmt.Errorf("truncated value list for key %q", k.name)
+	}
+
+	var values []registryValue
+	for i := 0; i < count; i++ {
+		v, err := k.hive.value(binary.LittleEndian.Uint32(list[i*4:]))
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, *v)
+	}
+	return values, nil
+}
+
+func (h *registryHive) value(offset uint32) (*registryValue, error) {
+	c, err := h.cell(offset)
+	if err != nil {
+		return nil, err
+	}
+	if len(c) < 0x14 || string(c[:2]) != "vk" {
+		return nil, fmt.Errorf("invalid value record at offset %#x", offset)
+	}
+
+	nameLength := int(binary.LittleEndian.Uint16(c[0x02:]))
+	size := binary.LittleEndian.Uint32(c[0x04:])
+	dataOffset := binary.LittleEndian.Uint32(c[0x08:])
+	flags := binary.LittleEndian.Uint16(c[0x10:])
+	if 0x14+nameLength > len(c) {
+		return nil, fmt.Errorf("invalid value name at offset %#x", offset)
+	}
+
+	v := &registryValue{
+		name:     decodeName(c[0x14:0x14+nameLength], flags&valueCompressedName != 0),
+		dataType: binary.LittleEndian.Uint32(c[0x0C:]),
+	}
+
+	switch {
+	case size&valueDataInline != 0:
+		size &^= valueDataInline
+		if size > 4 {
+			size = 4
+		}
+		v.data = c[0x08 : 0x08+size]
+	case size > bigDataThreshold:
+		v.data, err = h.bigData(dataOffset, size)
+	case size > 0:
+		var data []byte
+		data, err = h.cell(dataOffset)
+		if err == nil {
+			if int(size) > len(data) {
+				return nil, fmt.Errorf("truncated value data at offset %#x", dataOffset)
+			}
+			v.data = data[:size]
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func (h *registryHive) bigData(offset, size uint32) ([]byte, error) {
+	c, err := h.cell(offset)
+	if err != nil {
+		return nil, err
+	}
+	if len(c) < 8 || string(c[:2]) != "db" {
+		// hives prior to format version 1.4 store large values in a single cell
+		if uint32(len(c)) < size {
+			return nil, fmt.Errorf("truncated value data at offset %#x", offset)
+		}
+		return c[:size], nil
+	}
+
+	count := int(binary.LittleEndian.Uint16(c[2:]))
+	segments, err := h.cell(binary.LittleEndian.Uint32(c[4:]))
+	if err != nil {
+		return nil, err
+	}
+	if count*4 > len(segments) {
+		return nil, fmt.Errorf("truncated big data segment list at offset %#x", offset)
+	}
+
+	var data []byte
+	for i := 0; i < count && uint32(len(data)) < size; i++ {
+		segment, err := h.cell(binary.LittleEndian.Uint32(segments[i*4:]))
+		if err != nil {
+			return nil, err
+		}
+		if len(segment) > bigDataThreshold {
+			segment = segment[:bigDataThreshold]
+		}
+		data = append(data, segment...)
+	}
+	if uint32(len(data)) < size {
+		return nil, fmt.Errorf("truncated big data at offset %#x", offset)
+	}
+	return data[:size], nil
+}
+
+// Value returns the named value (case insensitive) of this key.
+func (k *registryKey) Value(name string) (*registryValue, bool) {
+	values, err := k.Values()
+	if err != nil {
+		return nil, false
+	}
+	for i := range values {
+		if strings.EqualFold(values[i].name, name) {
+			return &values[i], true
+		}
+	}
+	return nil, false
+}
+
+// String renders string and integer values, returning an empty string for any other type.
+func (v registryValue) String() string {
+	switch v.dataType {
+	case regSZ, regExpandSZ, regMultiSZ:
+		return strings.TrimRight(decodeUTF16(v.data), "\x00")
+	case regDWORD:
+		if len(v.data) >= 4 {
+			return fmt.Sprintf("%d", binary.LittleEndian.Uint32(v.data))
+		}
+	case regQWORD:
+		if len(v.data) >= 8 {
+			return fmt.Sprintf("%d", binary.LittleEndian.Uint64(v.data))
+		}
+	}
+	return ""
+}
+
+func decodeName(b []byte, compressed bool) string {
+	if !compressed {
+		return decodeUTF16(b)
+	}
+	// compressed names are latin-1 encoded
+	runes := make([]rune, len(b))
+	for i, c := range b {
+		runes[i] = rune(c)
+	}
+	return string(runes)
+}
+
+func decodeUTF16(b []byte) string {
+	u := make([]uint16, len(b)/2)
+	for i := range u {
+		u[i] = binary.LittleEndian.Uint16(b[i*2:])
+	}
+	return string(utf16.Decode(u))
+}
@@ -0,0 +1,90 @@
+package windows
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestHive lays the given subkey lists out as cells of 16 bytes, the list at index i being at offset 16*i.
+func newTestHive(t *testing.T, lists ...subkeyList) *registryHive {
+	t.Helper()
+
+	data := make([]byte, hiveBaseBlockSize+16*len(lists))
+	copy(data, hiveSignature)
+	for i, list := range lists {
+		require.LessOrEqual(t, len(list.offsets), 2)
+
+		c := data[hiveBaseBlockSize+16*i:]
+		binary.LittleEndian.PutUint32(c, uint32(0x100000000-16)) // allocated cells have a negative size
+		copy(c[4:], list.kind)
+		binary.LittleEndian.PutUint16(c[6:], uint16(len(list.offsets)))
+		for j, offset := range list.offsets {
+			binary.LittleEndian.PutUint32(c[8+4*j:], offset)
+		}
+	}
+
+	hive, err := newRegistryHive(data)
+	require.NoError(t, err)
+	return hive
+}
+
+type subkeyList struct {
+	kind    string
+	offsets []uint32
+}
+
+func TestRegistryHive_SubkeyOffsets(t *testing.T) {
+	tests := []struct {
+		name     string
+		lists    []subkeyList
+		expected []uint32
+		wantErr  bool
+	}{
+		{
+			name: "index root of subkey lists",
+			lists: []subkeyList{
+				{kind: "ri", offsets: []uint32{16, 32}},
+				{kind: "li", offsets: []uint32{0x100, 0x200}},
+				{kind: "li", offsets: []uint32{0x300}},
+			},
+			expected: []uint32{0x100, 0x200, 0x300},
+		},
+		{
+			name: "index root pointing to itself",
+			lists: []subkeyList{
+				{kind: "ri", offsets: []uint32{0}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "index roots pointing to each other",
+			lists: []subkeyList{
+				{kind: "ri", offsets: []uint32{16}},
+				{kind: "ri", offsets: []uint32{0}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "index root referencing a list twice",
+			lists: []subkeyList{
+				{kind: "ri", offsets: []uint32{16, 16}},
+				{kind: "li", offsets: []uint32{0x100}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			offsets, err := newTestHive(t, test.lists...).subkeyOffsets(0)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, offsets)
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package pkg
 
 import (
+	"strings"
+
 	"github.com/anchore/packageurl-go"
 	"github.com/lovewebshell/minicat/minicat/linux"
 )
@@ -8,20 +10,26 @@ import (
 var _ urlIdentifier = (*CocoapodsMetadata)(nil)
 
 type CocoapodsMetadata struct {
-	Name    string `mapstructure:"name" json:"name"`
-	Version string `mapstructure:"version" json:"version"`
-	PkgHash string `mapstructure:"pkgHash" json:"pkgHash"`
+	Name    string `mapstructure:"name" json:"name" cyclonedx:"name"`
+	Version string `mapstructure:"version" json:"version" cyclonedx:"version"`
+	PkgHash string `mapstructure:"pkgHash" json:"pkgHash" cyclonedx:"pkgHash"`
 }
 
 func (m CocoapodsMetadata) PackageURL(_ *linux.Release) string {
 	var qualifiers packageurl.Qualifiers
 
+	// subspecs are expressed as the subpath of the root pod
+	name, subspec := m.Name, ""
+	if fields := strings.SplitN(m.Name, "/", 2); len(fields) == 2 {
+		name, subspec = fields[0], fields[1]
+	}
+
 	return packageurl.NewPackageURL(
 		packageurl.TypeCocoapods,
 		"",
-		m.Name,
+		name,
 		m.Version,
 		qualifiers,
-		"",
+		subspec,
 	).ToString()
 }
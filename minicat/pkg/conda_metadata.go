@@ -0,0 +1,87 @@
+package pkg
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/anchore/packageurl-go"
+	"github.com/scylladb/go-set/strset"
+
+	"github.com/lovewebshell/minicat/minicat/linux"
+)
+
+const CondaMetaGlob = "**/conda-meta/*.json"
+
+var (
+	_ FileOwner     = (*CondaMetadata)(nil)
+	_ urlIdentifier = (*CondaMetadata)(nil)
+)
+
+// CondaMetadata is a package record of a conda environment. Files are the paths of the files installed by the package,
+// made absolute by the prefix of the environment the package is installed in.
+type CondaMetadata struct {
+	Name        string   `mapstructure:"name" json:"name"`
+	Version     string   `mapstructure:"version" json:"version"`
+	Build       string   `mapstructure:"build" json:"build"`
+	BuildNumber int      `mapstructure:"buildNumber" json:"buildNumber"`
+	Channel     string   `mapstructure:"channel" json:"channel"`
+	Subdir      string   `mapstructure:"subdir" json:"subdir"`
+	Filename    string   `mapstructure:"filename" json:"filename,omitempty"`
+	MD5         string   `mapstructure:"md5" json:"md5,omitempty"`
+	SHA256      string   `mapstructure:"sha256" json:"sha256,omitempty"`
+	Depends     []string `mapstructure:"depends" json:"depends,omitempty"`
+	Files       []string `mapstructure:"files" json:"files,omitempty"`
+}
+
+// PackageURL follows the conda purl definition, where the channel is its name ("conda-forge") rather than the URL
+// recorded by conda.
+func (m CondaMetadata) PackageURL(_ *linux.Release) string {
+	var qualifiers packageurl.Qualifiers
+	add := func(key, value string) {
+		if value != "" {
+			qualifiers = append(qualifiers, packageurl.Qualifier{Key: key, Value: value})
+		}
+	}
+	add("build", m.Build)
+	add("channel", m.channelName())
+	add("subdir", m.Subdir)
+	switch {
+	case strings.HasSuffix(m.Filename, ".conda"):
+		add("type", "conda")
+	case strings.HasSuffix(m.Filename, ".tar.bz2"):
+		add("type", "tar.bz2")
+	}
+
+	return packageurl.NewPackageURL(
+		packageurl.TypeConda,
+		"",
+		m.Name,
+		m.Version,
+		qualifiers,
+		"",
+	).ToString()
+}
+
+func (m CondaMetadata) channelName() string {
+	channel := strings.TrimSuffix(m.Channel, "/")
+	if m.Subdir != "" {
+		channel = strings.TrimSuffix(channel, "/"+m.Subdir)
+	}
+	if channel == "" {
+		return ""
+	}
+	return path.Base(channel)
+}
+
+func (m CondaMetadata) OwnedFiles() (result []string) {
+	s := strset.New()
+	for _, f := range m.Files {
+		if f != "" {
+			s.Add(f)
+		}
+	}
+	result = s.List()
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,41 @@
+package pkg
+
+import (
+	"github.com/anchore/packageurl-go"
+
+	"github.com/lovewebshell/minicat/minicat/linux"
+)
+
+var _ urlIdentifier = (*DartPubMetadata)(nil)
+
+type DartPubMetadata struct {
+	Name      string `mapstructure:"name" json:"name"`
+	Version   string `mapstructure:"version" json:"version"`
+	HostedURL string `mapstructure:"hosted_url" json:"hosted_url,omitempty"`
+	VcsURL    string `mapstructure:"vcs_url" json:"vcs_url,omitempty"`
+}
+
+func (m DartPubMetadata) PackageURL(_ *linux.Release) string {
+	var qualifiers packageurl.Qualifiers
+
+	if m.HostedURL != "" {
+		qualifiers = append(qualifiers, packageurl.Qualifier{
+			Key:   "hosted_url",
+			Value: m.HostedURL,
+		})
+	} else if m.VcsURL != "" {
+		qualifiers = append(qualifiers, packageurl.Qualifier{
+			Key:   PURLQualifierVCSURL,
+			Value: m.VcsURL,
+		})
+	}
+
+	return packageurl.NewPackageURL(
+		packageurl.TypePub,
+		"",
+		m.Name,
+		m.Version,
+		qualifiers,
+		"",
+	).ToString()
+}
@@ -0,0 +1,10 @@
+package pkg
+
+type DotnetDepsMetadata struct {
+	Name     string `mapstructure:"name" json:"name" cyclonedx:"name"`
+	Version  string `mapstructure:"version" json:"version" cyclonedx:"version"`
+	Type     string `mapstructure:"type" json:"type,omitempty" cyclonedx:"type"`
+	Path     string `mapstructure:"path" json:"path,omitempty" cyclonedx:"path"`
+	Sha512   string `mapstructure:"sha512" json:"sha512,omitempty" cyclonedx:"sha512"`
+	HashPath string `mapstructure:"hashPath" json:"hashPath,omitempty" cyclonedx:"hashPath"`
+}
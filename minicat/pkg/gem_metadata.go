@@ -1,5 +1,11 @@
 package pkg
 
+import (
+	"sort"
+
+	"github.com/scylladb/go-set/strset"
+)
+
 type GemMetadata struct {
 	Name     string   `mapstructure:"name" json:"name"`
 	Version  string   `mapstructure:"version" json:"version"`
@@ -8,3 +14,15 @@ type GemMetadata struct {
 	Licenses []string `mapstructure:"licenses" json:"licenses,omitempty"`
 	Homepage string   `mapstructure:"homepage" json:"homepage,omitempty"`
 }
+
+func (m GemMetadata) OwnedFiles() (result []string) {
+	s := strset.New()
+	for _, f := range m.Files {
+		if f != "" {
+			s.Add(f)
+		}
+	}
+	result = s.List()
+	sort.Strings(result)
+	return result
+}
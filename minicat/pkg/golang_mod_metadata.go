@@ -0,0 +1,7 @@
+package pkg
+
+type GolangModMetadata struct {
+	H1Digest string `json:"h1Digest,omitempty" cyclonedx:"h1Digest"`
+	Indirect bool   `json:"indirect,omitempty" cyclonedx:"indirect"`
+	Vendored bool   `json:"vendored,omitempty" cyclonedx:"vendored"`
+}
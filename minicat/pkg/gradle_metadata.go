@@ -0,0 +1,29 @@
+package pkg
+
+import (
+	"github.com/anchore/packageurl-go"
+
+	"github.com/lovewebshell/minicat/minicat/linux"
+)
+
+var _ urlIdentifier = (*GradleMetadata)(nil)
+
+// GradleMetadata is a module locked by a Gradle lockfile or declared by a version catalog. Configurations are the
+// configurations (compileClasspath, runtimeClasspath, ...) the lockfile resolved the module for.
+type GradleMetadata struct {
+	GroupID        string   `mapstructure:"groupId" json:"groupId" cyclonedx:"groupID"`
+	ArtifactID     string   `mapstructure:"artifactId" json:"artifactId" cyclonedx:"artifactID"`
+	Version        string   `mapstructure:"version" json:"version" cyclonedx:"version"`
+	Configurations []string `mapstructure:"configurations" json:"configurations,omitempty" cyclonedx:"configurations"`
+}
+
+func (m GradleMetadata) PackageURL(_ *linux.Release) string {
+	return packageurl.NewPackageURL(
+		packageurl.TypeMaven,
+		m.GroupID,
+		m.ArtifactID,
+		m.Version,
+		nil,
+		"",
+	).ToString()
+}
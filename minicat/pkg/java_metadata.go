@@ -15,14 +15,19 @@ var jenkinsPluginPomPropertiesGroupIDs = []string{
 	"com.cloudbees.jenkins.plugins",
 }
 
+// JavaMetadata describes a Java archive or an artifact shaded into one. RelocatedPackages are the packages the classes
+// of a shaded artifact were moved to (e.g. "com.acme.shaded.io.netty"), while Warnings tell which parts of the archive
+// were left out of the scan.
 type JavaMetadata struct {
-	VirtualPath    string         `json:"virtualPath" cyclonedx:"virtualPath"`
-	Manifest       *JavaManifest  `mapstructure:"Manifest" json:"manifest,omitempty"`
-	PomProperties  *PomProperties `mapstructure:"PomProperties" json:"pomProperties,omitempty" cyclonedx:"-"`
-	PomProject     *PomProject    `mapstructure:"PomProject" json:"pomProject,omitempty"`
-	ArchiveDigests []file.Digest  `hash:"ignore" json:"digest,omitempty"`
-	PURL           string         `hash:"ignore" json:"-"`
-	Parent         *Package       `hash:"ignore" json:"-"`
+	VirtualPath       string         `json:"virtualPath" cyclonedx:"virtualPath"`
+	Manifest          *JavaManifest  `mapstructure:"Manifest" json:"manifest,omitempty"`
+	PomProperties     *PomProperties `mapstructure:"PomProperties" json:"pomProperties,omitempty" cyclonedx:"-"`
+	PomProject        *PomProject    `mapstructure:"PomProject" json:"pomProject,omitempty"`
+	RelocatedPackages []string       `mapstructure:"RelocatedPackages" json:"relocatedPackages,omitempty" cyclonedx:"relocatedPackages"`
+	ArchiveDigests    []file.Digest  `hash:"ignore" json:"digest,omitempty"`
+	Warnings          []string       `hash:"ignore" json:"warnings,omitempty" cyclonedx:"warnings"`
+	PURL              string         `hash:"ignore" json:"-"`
+	Parent            *Package       `hash:"ignore" json:"-"`
 }
 
 type PomProperties struct {
@@ -31,6 +36,7 @@ type PomProperties struct {
 	GroupID    string            `mapstructure:"groupId" json:"groupId" cyclonedx:"groupID"`
 	ArtifactID string            `mapstructure:"artifactId" json:"artifactId" cyclonedx:"artifactID"`
 	Version    string            `mapstructure:"version" json:"version"`
+	Scope      string            `mapstructure:"scope" json:"scope,omitempty"`
 	Extra      map[string]string `mapstructure:",remain" json:"extraFields"`
 }
 
@@ -0,0 +1,48 @@
+package pkg
+
+import (
+	"github.com/anchore/packageurl-go"
+
+	"github.com/lovewebshell/minicat/minicat/linux"
+)
+
+var _ urlIdentifier = (*JavaVMInstallationMetadata)(nil)
+
+// JavaVMInstallationMetadata describes a JDK or JRE installation from its release file. Vendor and Product name the
+// distribution the way CPEs do (e.g. "eclipse" and "temurin"), while Implementor and ImplementorVersion are the values
+// recorded by the distribution itself.
+type JavaVMInstallationMetadata struct {
+	Vendor             string `json:"vendor"`
+	Product            string `json:"product"`
+	ImageType          string `json:"imageType"`
+	JavaHome           string `json:"javaHome"`
+	JavaVersion        string `json:"javaVersion"`
+	JavaRuntimeVersion string `json:"javaRuntimeVersion,omitempty"`
+	FullVersion        string `json:"fullVersion,omitempty"`
+	Implementor        string `json:"implementor,omitempty"`
+	ImplementorVersion string `json:"implementorVersion,omitempty"`
+	GraalVMVersion     string `json:"graalvmVersion,omitempty"`
+}
+
+// PackageURL is a generic purl namespaced by the vendor, as there is no purl type for JVM distributions.
+func (m JavaVMInstallationMetadata) PackageURL(_ *linux.Release) string {
+	return packageurl.NewPackageURL(
+		packageurl.TypeGeneric,
+		m.Vendor,
+		m.Product,
+		m.BuildVersion(),
+		nil,
+		"",
+	).ToString()
+}
+
+// BuildVersion is the most specific version of the installation, which includes the build number when it is recorded
+// (e.g. "17.0.8+7" or "1.8.0_372-b07").
+func (m JavaVMInstallationMetadata) BuildVersion() string {
+	for _, v := range []string{m.JavaRuntimeVersion, m.FullVersion} {
+		if v != "" {
+			return v
+		}
+	}
+	return m.JavaVersion
+}
@@ -13,6 +13,13 @@ const (
 	JavaScript      Language = "javascript"
 	Python          Language = "python"
 	Go              Language = "go"
+	Ruby            Language = "ruby"
+	Swift           Language = "swift"
+	Rust            Language = "rust"
+	PHP             Language = "php"
+	Dotnet          Language = "dotnet"
+	Dart            Language = "dart"
+	Elixir          Language = "elixir"
 	Maven           Language = "maven"
 	Gradle          Language = "gradle"
 )
@@ -22,6 +29,13 @@ var AllLanguages = []Language{
 	JavaScript,
 	Python,
 	Go,
+	Ruby,
+	Swift,
+	Rust,
+	PHP,
+	Dotnet,
+	Dart,
+	Elixir,
 	Maven,
 	Gradle,
 }
@@ -49,6 +63,20 @@ func LanguageByName(name string) Language {
 		return JavaScript
 	case packageurl.TypePyPi, string(Python):
 		return Python
+	case packageurl.TypeGem, string(Ruby):
+		return Ruby
+	case packageurl.TypeCocoapods, packageurl.TypeSwift, string(Swift):
+		return Swift
+	case purlCargoPkgType, string(RustPkg), string(Rust):
+		return Rust
+	case packageurl.TypeComposer, string(PhpComposerPkg), string(PHP):
+		return PHP
+	case packageurl.TypeNuget, string(Dotnet), ".net", "c#", "csharp":
+		return Dotnet
+	case packageurl.TypePub, string(DartPubPkg), string(Dart):
+		return Dart
+	case packageurl.TypeHex, string(Elixir):
+		return Elixir
 	default:
 		return UnknownLanguage
 	}
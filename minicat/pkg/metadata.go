@@ -7,17 +7,34 @@ import (
 type MetadataType string
 
 const (
-	UnknownMetadataType        MetadataType = "UnknownMetadata"
-	ApkMetadataType            MetadataType = "ApkMetadata"
-	AlpmMetadataType           MetadataType = "AlpmMetadata"
-	DpkgMetadataType           MetadataType = "DpkgMetadata"
-	GemMetadataType            MetadataType = "GemMetadata"
-	JavaMetadataType           MetadataType = "JavaMetadata"
-	NpmPackageJSONMetadataType MetadataType = "NpmPackageJsonMetadata"
-	RpmMetadataType            MetadataType = "RpmMetadata"
-	PythonPackageMetadataType  MetadataType = "PythonPackageMetadata"
-	KbPackageMetadataType      MetadataType = "KbPackageMetadata"
-	GolangBinMetadataType      MetadataType = "GolangBinMetadata"
+	UnknownMetadataType             MetadataType = "UnknownMetadata"
+	ApkMetadataType                 MetadataType = "ApkMetadata"
+	AlpmMetadataType                MetadataType = "AlpmMetadata"
+	DpkgMetadataType                MetadataType = "DpkgMetadata"
+	GemMetadataType                 MetadataType = "GemMetadata"
+	JavaMetadataType                MetadataType = "JavaMetadata"
+	GradleMetadataType              MetadataType = "GradleMetadata"
+	JavaVMInstallationMetadataType  MetadataType = "JavaVMInstallationMetadata"
+	NpmPackageJSONMetadataType      MetadataType = "NpmPackageJsonMetadata"
+	NpmPackageLockJSONMetadataType  MetadataType = "NpmPackageLockJsonMetadata"
+	PnpmLockMetadataType            MetadataType = "PnpmLockMetadata"
+	YarnLockMetadataType            MetadataType = "YarnLockMetadata"
+	RpmMetadataType                 MetadataType = "RpmMetadata"
+	PythonPackageMetadataType       MetadataType = "PythonPackageMetadata"
+	PythonRequirementsMetadataType  MetadataType = "PythonRequirementsMetadata"
+	PythonUvLockMetadataType        MetadataType = "PythonUvLockMetadata"
+	PythonPdmLockMetadataType       MetadataType = "PythonPdmLockMetadata"
+	KbPackageMetadataType           MetadataType = "KbPackageMetadata"
+	GolangBinMetadataType           MetadataType = "GolangBinMetadata"
+	GolangModMetadataType           MetadataType = "GolangModMetadata"
+	CocoapodsMetadataType           MetadataType = "CocoapodsMetadata"
+	RustCargoPackageMetadataType    MetadataType = "RustCargoPackageMetadata"
+	PhpComposerJSONMetadataType     MetadataType = "PhpComposerJsonMetadata"
+	DotnetDepsMetadataType          MetadataType = "DotnetDepsMetadata"
+	DartPubMetadataType             MetadataType = "DartPubMetadata"
+	SwiftPackageManagerMetadataType MetadataType = "SwiftPackageManagerMetadata"
+	MixLockMetadataType             MetadataType = "MixLockMetadata"
+	CondaMetadataType               MetadataType = "CondaMetadata"
 )
 
 var AllMetadataTypes = []MetadataType{
@@ -26,20 +43,56 @@ var AllMetadataTypes = []MetadataType{
 	DpkgMetadataType,
 	GemMetadataType,
 	JavaMetadataType,
+	GradleMetadataType,
+	JavaVMInstallationMetadataType,
 	NpmPackageJSONMetadataType,
+	NpmPackageLockJSONMetadataType,
+	PnpmLockMetadataType,
+	YarnLockMetadataType,
 	RpmMetadataType,
 	PythonPackageMetadataType,
+	PythonRequirementsMetadataType,
+	PythonUvLockMetadataType,
+	PythonPdmLockMetadataType,
 	KbPackageMetadataType,
 	GolangBinMetadataType,
+	GolangModMetadataType,
+	CocoapodsMetadataType,
+	RustCargoPackageMetadataType,
+	PhpComposerJSONMetadataType,
+	DotnetDepsMetadataType,
+	DartPubMetadataType,
+	SwiftPackageManagerMetadataType,
+	MixLockMetadataType,
+	CondaMetadataType,
 }
 
 var MetadataTypeByName = map[MetadataType]reflect.Type{
-	ApkMetadataType:            reflect.TypeOf(ApkMetadata{}),
-	AlpmMetadataType:           reflect.TypeOf(AlpmMetadata{}),
-	JavaMetadataType:           reflect.TypeOf(JavaMetadata{}),
-	NpmPackageJSONMetadataType: reflect.TypeOf(NpmPackageJSONMetadata{}),
-	RpmMetadataType:            reflect.TypeOf(RpmMetadata{}),
-	PythonPackageMetadataType:  reflect.TypeOf(PythonPackageMetadata{}),
-	KbPackageMetadataType:      reflect.TypeOf(KbPackageMetadata{}),
-	GolangBinMetadataType:      reflect.TypeOf(GolangBinMetadata{}),
+	ApkMetadataType:                 reflect.TypeOf(ApkMetadata{}),
+	AlpmMetadataType:                reflect.TypeOf(AlpmMetadata{}),
+	DpkgMetadataType:                reflect.TypeOf(DpkgMetadata{}),
+	GemMetadataType:                 reflect.TypeOf(GemMetadata{}),
+	JavaMetadataType:                reflect.TypeOf(JavaMetadata{}),
+	GradleMetadataType:              reflect.TypeOf(GradleMetadata{}),
+	JavaVMInstallationMetadataType:  reflect.TypeOf(JavaVMInstallationMetadata{}),
+	NpmPackageJSONMetadataType:      reflect.TypeOf(NpmPackageJSONMetadata{}),
+	NpmPackageLockJSONMetadataType:  reflect.TypeOf(NpmPackageLockJSONMetadata{}),
+	PnpmLockMetadataType:            reflect.TypeOf(PnpmLockMetadata{}),
+	YarnLockMetadataType:            reflect.TypeOf(YarnLockMetadata{}),
+	RpmMetadataType:                 reflect.TypeOf(RpmMetadata{}),
+	PythonPackageMetadataType:       reflect.TypeOf(PythonPackageMetadata{}),
+	PythonRequirementsMetadataType:  reflect.TypeOf(PythonRequirementsMetadata{}),
+	PythonUvLockMetadataType:        reflect.TypeOf(PythonUvLockMetadata{}),
+	PythonPdmLockMetadataType:       reflect.TypeOf(PythonPdmLockMetadata{}),
+	KbPackageMetadataType:           reflect.TypeOf(KbPackageMetadata{}),
+	GolangBinMetadataType:           reflect.TypeOf(GolangBinMetadata{}),
+	GolangModMetadataType:           reflect.TypeOf(GolangModMetadata{}),
+	CocoapodsMetadataType:           reflect.TypeOf(CocoapodsMetadata{}),
+	RustCargoPackageMetadataType:    reflect.TypeOf(CargoPackageMetadata{}),
+	PhpComposerJSONMetadataType:     reflect.TypeOf(PhpComposerJSONMetadata{}),
+	DotnetDepsMetadataType:          reflect.TypeOf(DotnetDepsMetadata{}),
+	DartPubMetadataType:             reflect.TypeOf(DartPubMetadata{}),
+	SwiftPackageManagerMetadataType: reflect.TypeOf(SwiftPackageManagerMetadata{}),
+	MixLockMetadataType:             reflect.TypeOf(MixLockMetadata{}),
+	CondaMetadataType:               reflect.TypeOf(CondaMetadata{}),
 }
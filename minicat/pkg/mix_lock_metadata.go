@@ -0,0 +1,8 @@
+package pkg
+
+type MixLockMetadata struct {
+	Name       string `mapstructure:"name" json:"name" cyclonedx:"name"`
+	Version    string `mapstructure:"version" json:"version" cyclonedx:"version"`
+	PkgHash    string `mapstructure:"pkgHash" json:"pkgHash" cyclonedx:"pkgHash"`
+	PkgHashExt string `mapstructure:"pkgHashExt" json:"pkgHashExt" cyclonedx:"pkgHashExt"`
+}
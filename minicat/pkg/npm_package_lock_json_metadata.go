@@ -0,0 +1,9 @@
+package pkg
+
+type NpmPackageLockJSONMetadata struct {
+	Resolved  string `mapstructure:"resolved" json:"resolved"`
+	Integrity string `mapstructure:"integrity" json:"integrity"`
+	Dev       bool   `mapstructure:"dev" json:"dev,omitempty"`
+	Optional  bool   `mapstructure:"optional" json:"optional,omitempty"`
+	Peer      bool   `mapstructure:"peer" json:"peer,omitempty"`
+}
@@ -0,0 +1,8 @@
+package pkg
+
+type PnpmLockMetadata struct {
+	Integrity string `mapstructure:"integrity" json:"integrity,omitempty"`
+	Tarball   string `mapstructure:"tarball" json:"tarball,omitempty"`
+	Dev       bool   `mapstructure:"dev" json:"dev,omitempty"`
+	Optional  bool   `mapstructure:"optional" json:"optional,omitempty"`
+}
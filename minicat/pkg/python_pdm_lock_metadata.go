@@ -0,0 +1,7 @@
+package pkg
+
+type PythonPdmLockMetadata struct {
+	Groups  []string `mapstructure:"groups" json:"groups,omitempty"`
+	Markers string   `mapstructure:"markers" json:"markers,omitempty"`
+	Hashes  []string `mapstructure:"hashes" json:"hashes,omitempty"`
+}
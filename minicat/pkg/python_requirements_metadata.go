@@ -0,0 +1,10 @@
+package pkg
+
+// PythonRequirementsMetadata describes a dependency as declared by a project rather than as resolved, where the
+// package version is only known when the requirement pins it.
+type PythonRequirementsMetadata struct {
+	Name              string   `mapstructure:"name" json:"name"`
+	Extras            []string `mapstructure:"extras" json:"extras,omitempty"`
+	VersionConstraint string   `mapstructure:"versionConstraint" json:"versionConstraint"`
+	Markers           string   `mapstructure:"markers" json:"markers,omitempty"`
+}
@@ -0,0 +1,8 @@
+package pkg
+
+type PythonUvLockMetadata struct {
+	Source  string   `mapstructure:"source" json:"source,omitempty"`
+	Hashes  []string `mapstructure:"hashes" json:"hashes,omitempty"`
+	Markers []string `mapstructure:"markers" json:"markers,omitempty"`
+	Extras  []string `mapstructure:"extras" json:"extras,omitempty"`
+}
@@ -19,7 +19,7 @@ var globsForbiddenFromBeingOwned = []string{
 	"/usr/share/doc/**/copyright",
 }
 
-type ownershipByFilesMetadata struct {
+type OwnershipByFilesMetadata struct {
 	Files []string `json:"files"`
 }
 
@@ -35,7 +35,7 @@ func RelationshipsByFileOwnership(catalog *Catalog) []artifact.Relationship {
 				From: catalog.byID[parentID],
 				To:   catalog.byID[childID],
 				Type: artifact.OwnershipByFileOverlapRelationship,
-				Data: ownershipByFilesMetadata{
+				Data: OwnershipByFilesMetadata{
 					Files: fs,
 				},
 			})
@@ -0,0 +1,46 @@
+package pkg
+
+import (
+	"strings"
+
+	"github.com/anchore/packageurl-go"
+
+	"github.com/lovewebshell/minicat/minicat/linux"
+)
+
+var _ urlIdentifier = (*SwiftPackageManagerMetadata)(nil)
+
+type SwiftPackageManagerMetadata struct {
+	Name          string `mapstructure:"name" json:"name"`
+	Version       string `mapstructure:"version" json:"version"`
+	RepositoryURL string `mapstructure:"repositoryURL" json:"repositoryURL"`
+	Revision      string `mapstructure:"revision" json:"revision"`
+	Branch        string `mapstructure:"branch" json:"branch,omitempty"`
+}
+
+// PackageURL expresses the package by the location of its repository, so "https://github.com/apple/swift-nio.git"
+// becomes "pkg:swift/github.com/apple/swift-nio@<version>".
+func (m SwiftPackageManagerMetadata) PackageURL(_ *linux.Release) string {
+	location := m.RepositoryURL
+	if i := strings.Index(location, "://"); i >= 0 {
+		location = location[i+3:]
+	} else if i := strings.Index(location, "@"); i >= 0 {
+		// scp-like git locations, such as git@github.com:apple/swift-nio.git
+		location = strings.Replace(location[i+1:], ":", "/", 1)
+	}
+	location = strings.TrimSuffix(strings.TrimSuffix(location, "/"), ".git")
+
+	namespace, name := "", m.Name
+	if i := strings.LastIndex(location, "/"); i >= 0 {
+		namespace, name = location[:i], location[i+1:]
+	}
+
+	return packageurl.NewPackageURL(
+		packageurl.TypeSwift,
+		namespace,
+		name,
+		m.Version,
+		nil,
+		"",
+	).ToString()
+}
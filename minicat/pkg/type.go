@@ -5,16 +5,26 @@ import "github.com/anchore/packageurl-go"
 type Type string
 
 const (
-	UnknownPkg  Type = "UnknownPackage"
-	ApkPkg      Type = "apk"
-	AlpmPkg     Type = "alpm"
-	GemPkg      Type = "gem"
-	DebPkg      Type = "deb"
-	RpmPkg      Type = "rpm"
-	NpmPkg      Type = "npm"
-	PythonPkg   Type = "python"
-	JavaPkg     Type = "java-archive"
-	GoModulePkg Type = "go-module"
+	UnknownPkg     Type = "UnknownPackage"
+	ApkPkg         Type = "apk"
+	AlpmPkg        Type = "alpm"
+	GemPkg         Type = "gem"
+	DebPkg         Type = "deb"
+	RpmPkg         Type = "rpm"
+	NpmPkg         Type = "npm"
+	PythonPkg      Type = "python"
+	JavaPkg        Type = "java-archive"
+	GoModulePkg    Type = "go-module"
+	PodPkg         Type = "pod"
+	KbPkg          Type = "msrc-kb"
+	RustPkg        Type = "rust-crate"
+	PhpComposerPkg Type = "php-composer"
+	NugetPkg       Type = "nuget"
+	DartPubPkg     Type = "dart-pub"
+	SwiftPkg       Type = "swift"
+	HexPkg         Type = "hex"
+	CondaPkg       Type = "conda"
+	BinaryPkg      Type = "binary"
 )
 
 func (t Type) PackageURLType() string {
@@ -35,7 +45,77 @@ func (t Type) PackageURLType() string {
 		return packageurl.TypeRPM
 	case GoModulePkg:
 		return packageurl.TypeGolang
+	case PodPkg:
+		return packageurl.TypeCocoapods
+	case RustPkg:
+		return purlCargoPkgType
+	case PhpComposerPkg:
+		return packageurl.TypeComposer
+	case NugetPkg:
+		return packageurl.TypeNuget
+	case DartPubPkg:
+		return packageurl.TypePub
+	case SwiftPkg:
+		return packageurl.TypeSwift
+	case HexPkg:
+		return packageurl.TypeHex
+	case CondaPkg:
+		return packageurl.TypeConda
+	case BinaryPkg:
+		return packageurl.TypeGeneric
 	default:
 		return ""
 	}
 }
+
+func TypeFromPURL(p string) Type {
+	purl, err := packageurl.FromString(p)
+	if err != nil {
+		return UnknownPkg
+	}
+
+	return TypeByName(purl.Type)
+}
+
+func TypeByName(name string) Type {
+	switch name {
+	case "alpine", "apk":
+		return ApkPkg
+	case "alpm":
+		return AlpmPkg
+	case packageurl.TypeGem:
+		return GemPkg
+	case packageurl.TypeDebian:
+		return DebPkg
+	case packageurl.TypePyPi:
+		return PythonPkg
+	case packageurl.TypeNPM:
+		return NpmPkg
+	case packageurl.TypeRPM:
+		return RpmPkg
+	case packageurl.TypeMaven, purlGradlePkgType:
+		return JavaPkg
+	case packageurl.TypeGolang:
+		return GoModulePkg
+	case packageurl.TypeCocoapods:
+		return PodPkg
+	case purlCargoPkgType, string(RustPkg):
+		return RustPkg
+	case packageurl.TypeComposer, string(PhpComposerPkg):
+		return PhpComposerPkg
+	case packageurl.TypeNuget:
+		return NugetPkg
+	case packageurl.TypePub, string(DartPubPkg):
+		return DartPubPkg
+	case packageurl.TypeSwift:
+		return SwiftPkg
+	case packageurl.TypeHex:
+		return HexPkg
+	case packageurl.TypeConda:
+		return CondaPkg
+	case string(BinaryPkg):
+		return BinaryPkg
+	default:
+		return UnknownPkg
+	}
+}
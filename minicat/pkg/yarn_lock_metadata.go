@@ -0,0 +1,6 @@
+package pkg
+
+type YarnLockMetadata struct {
+	Resolution string `mapstructure:"resolution" json:"resolution"`
+	Checksum   string `mapstructure:"checksum" json:"checksum,omitempty"`
+}
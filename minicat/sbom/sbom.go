@@ -0,0 +1,23 @@
+/*
+Package sbom provides the data structure holding the full result of a cataloging run, which is the input to (and
+output of) every SBOM format encoder and decoder.
+*/
+package sbom
+
+import (
+	"github.com/lovewebshell/minicat/minicat/artifact"
+	"github.com/lovewebshell/minicat/minicat/linux"
+	"github.com/lovewebshell/minicat/minicat/pkg"
+	"github.com/lovewebshell/minicat/minicat/source"
+)
+
+type SBOM struct {
+	Artifacts     Artifacts
+	Relationships []artifact.Relationship
+	Source        source.Metadata
+}
+
+type Artifacts struct {
+	PackageCatalog    *pkg.Catalog
+	LinuxDistribution *linux.Release
+}
@@ -2,6 +2,7 @@ package source
 
 import (
 	"archive/tar"
+	"context"
 	"fmt"
 	"io"
 
@@ -112,12 +113,15 @@ func (r *allLayersResolver) FilesByPath(paths ...string) ([]Location, error) {
 	return uniqueLocations, nil
 }
 
-func (r *allLayersResolver) FilesByGlob(patterns ...string) ([]Location, error) {
+func (r *allLayersResolver) FilesByGlob(ctx context.Context, patterns ...string) ([]Location, error) {
 	uniqueFileIDs := file.NewFileReferenceSet()
 	uniqueLocations := make([]Location, 0)
 
 	for _, pattern := range patterns {
 		for idx, layerIdx := range r.layers {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
 			results, err := r.img.Layers[layerIdx].Tree.FilesByGlob(pattern, filetree.FollowBasenameLinks, filetree.DoNotFollowDeadBasenameLinks)
 			if err != nil {
 				return nil, fmt.Errorf("failed to resolve files by glob (%s): %w", pattern, err)
@@ -209,14 +213,18 @@ func (r *allLayersResolver) FilesByMIMEType(types ...string) ([]Location, error)
 	return locations, nil
 }
 
-func (r *allLayersResolver) AllLocations() <-chan Location {
+func (r *allLayersResolver) AllLocations(ctx context.Context) <-chan Location {
 	results := make(chan Location)
 	go func() {
 		defer close(results)
 		for _, layerIdx := range r.layers {
 			tree := r.img.Layers[layerIdx].Tree
 			for _, ref := range tree.AllFiles(file.AllTypes...) {
-				results <- NewLocationFromImage(string(ref.RealPath), ref, r.img)
+				select {
+				case <-ctx.Done():
+					return
+				case results <- NewLocationFromImage(string(ref.RealPath), ref, r.img):
+				}
 			}
 		}
 	}()
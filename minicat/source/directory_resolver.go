@@ -1,6 +1,7 @@
 package source
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -354,10 +355,13 @@ func (r directoryResolver) FilesByPath(userPaths ...string) ([]Location, error)
 	return references, nil
 }
 
-func (r directoryResolver) FilesByGlob(patterns ...string) ([]Location, error) {
+func (r directoryResolver) FilesByGlob(ctx context.Context, patterns ...string) ([]Location, error) {
 	result := make([]Location, 0)
 
 	for _, pattern := range patterns {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		globResults, err := r.fileTree.FilesByGlob(pattern, filetree.FollowBasenameLinks)
 		if err != nil {
 			return nil, err
@@ -410,13 +414,17 @@ func (r directoryResolver) isInIndex(location Location) bool {
 	return r.fileTree.HasPath(location.ref.RealPath, filetree.FollowBasenameLinks)
 }
 
-func (r *directoryResolver) AllLocations() <-chan Location {
+func (r *directoryResolver) AllLocations(ctx context.Context) <-chan Location {
 	results := make(chan Location)
 	go func() {
 		defer close(results)
 
 		for _, ref := range r.fileTree.AllFiles(file.TypeReg, file.TypeSymlink, file.TypeHardLink, file.TypeBlockDevice, file.TypeCharacterDevice, file.TypeFifo) {
-			results <- NewLocationFromDirectory(r.responsePath(string(ref.RealPath)), ref)
+			select {
+			case <-ctx.Done():
+				return
+			case results <- NewLocationFromDirectory(r.responsePath(string(ref.RealPath)), ref):
+			}
 		}
 	}()
 	return results
@@ -1,6 +1,7 @@
 package source
 
 import (
+	"context"
 	"fmt"
 	"io"
 )
@@ -45,8 +46,8 @@ func (r *excludingResolver) FilesByPath(paths ...string) ([]Location, error) {
 	return filterLocations(locations, err, r.excludeFn)
 }
 
-func (r *excludingResolver) FilesByGlob(patterns ...string) ([]Location, error) {
-	locations, err := r.delegate.FilesByGlob(patterns...)
+func (r *excludingResolver) FilesByGlob(ctx context.Context, patterns ...string) ([]Location, error) {
+	locations, err := r.delegate.FilesByGlob(ctx, patterns...)
 	return filterLocations(locations, err, r.excludeFn)
 }
 
@@ -63,13 +64,18 @@ func (r *excludingResolver) RelativeFileByPath(location Location, path string) *
 	return l
 }
 
-func (r *excludingResolver) AllLocations() <-chan Location {
+func (r *excludingResolver) AllLocations(ctx context.Context) <-chan Location {
 	c := make(chan Location)
 	go func() {
 		defer close(c)
-		for location := range r.delegate.AllLocations() {
-			if !locationMatches(&location, r.excludeFn) {
-				c <- location
+		for location := range r.delegate.AllLocations(ctx) {
+			if locationMatches(&location, r.excludeFn) {
+				continue
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case c <- location:
 			}
 		}
 	}()
@@ -1,6 +1,7 @@
 package source
 
 import (
+	"context"
 	"io"
 )
 
@@ -24,7 +25,7 @@ type FilePathResolver interface {
 
 	FilesByPath(paths ...string) ([]Location, error)
 
-	FilesByGlob(patterns ...string) ([]Location, error)
+	FilesByGlob(ctx context.Context, patterns ...string) ([]Location, error)
 
 	FilesByMIMEType(types ...string) ([]Location, error)
 
@@ -32,5 +33,5 @@ type FilePathResolver interface {
 }
 
 type FileLocationResolver interface {
-	AllLocations() <-chan Location
+	AllLocations(ctx context.Context) <-chan Location
 }
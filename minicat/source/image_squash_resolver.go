@@ -2,6 +2,7 @@ package source
 
 import (
 	"archive/tar"
+	"context"
 	"fmt"
 	"io"
 
@@ -71,11 +72,14 @@ func (r *imageSquashResolver) FilesByPath(paths ...string) ([]Location, error) {
 	return uniqueLocations, nil
 }
 
-func (r *imageSquashResolver) FilesByGlob(patterns ...string) ([]Location, error) {
+func (r *imageSquashResolver) FilesByGlob(ctx context.Context, patterns ...string) ([]Location, error) {
 	uniqueFileIDs := file.NewFileReferenceSet()
 	uniqueLocations := make([]Location, 0)
 
 	for _, pattern := range patterns {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		results, err := r.img.SquashedTree().FilesByGlob(pattern, filetree.FollowBasenameLinks)
 		if err != nil {
 			return nil, fmt.Errorf("failed to resolve files by glob (%s): %w", pattern, err)
@@ -152,12 +156,16 @@ func (r *imageSquashResolver) FileContentsByLocation(location Location) (io.Read
 	return r.img.FileContentsByRef(location.ref)
 }
 
-func (r *imageSquashResolver) AllLocations() <-chan Location {
+func (r *imageSquashResolver) AllLocations(ctx context.Context) <-chan Location {
 	results := make(chan Location)
 	go func() {
 		defer close(results)
 		for _, ref := range r.img.SquashedTree().AllFiles(file.AllTypes...) {
-			results <- NewLocationFromImage(string(ref.RealPath), ref, r.img)
+			select {
+			case <-ctx.Done():
+				return
+			case results <- NewLocationFromImage(string(ref.RealPath), ref, r.img):
+			}
 		}
 	}()
 	return results
@@ -1,6 +1,7 @@
 package source
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
@@ -79,9 +80,12 @@ func (r MockResolver) FilesByPath(paths ...string) ([]Location, error) {
 	return results, nil
 }
 
-func (r MockResolver) FilesByGlob(patterns ...string) ([]Location, error) {
+func (r MockResolver) FilesByGlob(ctx context.Context, patterns ...string) ([]Location, error) {
 	var results []Location
 	for _, pattern := range patterns {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		for _, location := range r.locations {
 			matches, err := doublestar.Match(pattern, location.RealPath)
 			if err != nil {
@@ -109,12 +113,16 @@ func (r MockResolver) RelativeFileByPath(_ Location, path string) *Location {
 	return &paths[0]
 }
 
-func (r MockResolver) AllLocations() <-chan Location {
+func (r MockResolver) AllLocations(ctx context.Context) <-chan Location {
 	results := make(chan Location)
 	go func() {
 		defer close(results)
 		for _, l := range r.locations {
-			results <- l
+			select {
+			case <-ctx.Done():
+				return
+			case results <- l:
+			}
 		}
 	}()
 	return results